@@ -0,0 +1,39 @@
+package mobileesp
+
+import "testing"
+
+func TestBot(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		bot       bool
+		mobileBot bool
+	}{
+		{"Mozilla/5.0 (Linux; Android 6.0.1; Nexus 5X Build/MMB29P) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/41.0.2272.96 Mobile Safari/537.36 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", true, true},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 7_0 like Mac OS X) AppleWebKit/537.51.1 (KHTML, like Gecko) Version/7.0 Mobile/11A465 Safari/9537.53 (compatible; bingbot/2.0; +http://www.bing.com/bingbot.htm)", true, true},
+		{"Mozilla/5.0 (Linux; Android 5.0; SM-G900P Build/LRX21T) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/41.0.2272.96 Mobile Safari/537.36 (compatible; AdsBot-Google-Mobile; +http://www.google.com/mobile/adsbot.html)", true, true},
+		{"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", true, false},
+		{"facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)", true, false},
+		{"Sogou web spider/4.0(+http://www.sogou.com/docs/help/webmasters.htm#07)", true, false},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 8_3 like Mac OS X) AppleWebKit/600.1.4 (KHTML, like Gecko) Version/8.0 Mobile/12F70 Safari/600.1.4", false, false},
+		//The Sogou browser is not the Sogou crawler.
+		{"Mozilla/5.0 (Linux; Android 10; V2002A) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/86.0.4240.99 Mobile Safari/537.36 SogouMobileBrowser/5.29.10", false, false},
+	}
+
+	for _, test := range tests {
+		device := NewFromStrings(test.userAgent, "").Device()
+		if got := device.Bot(); got != test.bot {
+			t.Errorf("Bot() = %v, want %v\nUA: %s", got, test.bot, test.userAgent)
+		}
+		if got := device.MobileBot(); got != test.mobileBot {
+			t.Errorf("MobileBot() = %v, want %v\nUA: %s", got, test.mobileBot, test.userAgent)
+		}
+
+		excluding := device.ExcludingBots()
+		if test.bot && (excluding.MobileQuick() || excluding.TierIphone() || excluding.TierOtherPhones()) {
+			t.Errorf("ExcludingBots() reports a bot as mobile\nUA: %s", test.userAgent)
+		}
+		if !test.bot && excluding.TierIphone() != device.TierIphone() {
+			t.Errorf("ExcludingBots() changed TierIphone() for a non-bot\nUA: %s", test.userAgent)
+		}
+	}
+}
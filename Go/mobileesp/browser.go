@@ -0,0 +1,187 @@
+package mobileesp
+
+import (
+	"regexp"
+	"strings"
+)
+
+// BrowserFamily is the browser reported by Browser().
+type BrowserFamily string
+
+const (
+	BrowserUnknown   BrowserFamily = ""
+	BrowserChrome    BrowserFamily = "chrome"
+	BrowserSafari    BrowserFamily = "safari"
+	BrowserSamsung   BrowserFamily = "samsung"
+	BrowserUC        BrowserFamily = "ucbrowser"
+	BrowserFirefox   BrowserFamily = "firefox"
+	BrowserEdge      BrowserFamily = "edge"
+	BrowserOpera     BrowserFamily = "opera"
+	BrowserSilk      BrowserFamily = "silk"
+	BrowserIE        BrowserFamily = "ie"
+	BrowserAndroid   BrowserFamily = "android" //The stock Android browser before Chrome.
+	BrowserFacebook  BrowserFamily = "facebook"
+	BrowserInstagram BrowserFamily = "instagram"
+	BrowserWebView   BrowserFamily = "webview" //Another app's Android wv or iOS WebView.
+)
+
+// Browser is the browser family and version reported by Browser().
+type Browser struct {
+	Family  BrowserFamily
+	Version Version
+	WebView bool //The page is shown inside another app rather than a browser.
+}
+
+type browserPattern struct {
+	family  BrowserFamily
+	webView bool
+	any     []string //The UA contains any of these tokens.
+	all     []string //The UA contains all of these tokens.
+	version []*regexp.Regexp
+}
+
+//**************************
+// Tried in order. The first pattern whose tokens match wins. Apps and
+//   Chrome-based browsers come first, since their UAs also claim
+//   Chrome and Safari.
+var browserPatterns = []browserPattern{
+	{
+		family: BrowserFacebook, webView: true,
+		any:     []string{"fban/", "fbav/", "fb_iab/"},
+		version: []*regexp.Regexp{regexp.MustCompile(`fbav/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	{
+		family: BrowserInstagram, webView: true,
+		any:     []string{"instagram "},
+		version: []*regexp.Regexp{regexp.MustCompile(`instagram (\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	{
+		family:  BrowserEdge,
+		any:     []string{"edg/", "edge/", "edga/", "edgios/"},
+		version: []*regexp.Regexp{regexp.MustCompile(`edg(?:e|a|ios)?/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	{
+		family: BrowserOpera,
+		any:    []string{"opr/", "opios/", engineOpera},
+		version: []*regexp.Regexp{
+			regexp.MustCompile(`(?:opr|opios|opera mini)/(\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+			regexp.MustCompile(`version/(\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+			regexp.MustCompile(`opera[/ ](\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+		},
+	},
+	{
+		family:  BrowserSamsung,
+		any:     []string{"samsungbrowser/"},
+		version: []*regexp.Regexp{regexp.MustCompile(`samsungbrowser/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	{
+		family:  BrowserUC,
+		any:     []string{"ucbrowser", "ucweb"},
+		version: []*regexp.Regexp{regexp.MustCompile(`ucbrowser/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	{
+		family:  BrowserSilk,
+		any:     []string{engineSilk, "silk/"},
+		version: []*regexp.Regexp{regexp.MustCompile(`silk/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	{
+		family:  BrowserFirefox,
+		any:     []string{engineFirefox, "fxios/"},
+		version: []*regexp.Regexp{regexp.MustCompile(`(?:firefox|fxios)/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	{
+		family: BrowserIE,
+		any:    []string{deviceIeMob, "msie ", "trident/"},
+		version: []*regexp.Regexp{
+			regexp.MustCompile(`iemobile[/ ](\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+			regexp.MustCompile(`msie (\d+)(?:\.(\d+))?`),
+			regexp.MustCompile(`rv:(\d+)(?:\.(\d+))?`),
+		},
+	},
+	//Android System WebView marks its UA with "; wv)".
+	{
+		family: BrowserWebView, webView: true,
+		all:     []string{deviceAndroid, "; wv)"},
+		version: []*regexp.Regexp{regexp.MustCompile(`chrome/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	{
+		family:  BrowserChrome,
+		any:     []string{"chrome/", "chromium/", "crios/"},
+		version: []*regexp.Regexp{regexp.MustCompile(`(?:chrome|chromium|crios)/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	{
+		family:  BrowserAndroid,
+		all:     []string{deviceAndroid, "version/"},
+		version: []*regexp.Regexp{regexp.MustCompile(`version/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	{
+		family:  BrowserSafari,
+		all:     []string{"safari/", "version/"},
+		version: []*regexp.Regexp{regexp.MustCompile(`version/(\d+)(?:\.(\d+))?(?:\.(\d+))?`)},
+	},
+	//iOS apps show pages in a WebView whose UA leaves out Safari/.
+	{
+		family: BrowserWebView, webView: true,
+		all: []string{"like mac os x", "mobile/"},
+	},
+}
+
+// Sec-CH-UA brands that name a browser family.
+var browserBrands = map[string]BrowserFamily{
+	"google chrome":    BrowserChrome,
+	"microsoft edge":   BrowserEdge,
+	"opera":            BrowserOpera,
+	"samsung internet": BrowserSamsung,
+}
+
+//**************************
+// Returns the browser family and version, such as Chrome 114.0.5735 or
+//   Samsung Internet 21.0. In-app browsers report the app, such as
+//   Facebook, with WebView set. Returns BrowserUnknown when no known
+//   browser token is found. A Sec-CH-UA brand wins over the User Agent.
+func (base *UAgentInfo) Browser() Browser {
+	browser := matchBrowser(base.userAgentHeader)
+	for _, brand := range base.clientHints.Brands {
+		family, ok := browserBrands[strings.ToLower(brand.Name)]
+		if !ok || browser.WebView {
+			continue
+		}
+		if family != browser.Family {
+			browser = Browser{Family: family, Version: parseVersion(strings.Split(brand.Version, "."))}
+		}
+		break
+	}
+	return browser
+}
+
+func matchBrowser(userAgent string) Browser {
+	for _, pattern := range browserPatterns {
+		if !containsAll(userAgent, pattern.all) || len(pattern.any) > 0 && !containsAny(userAgent, pattern.any) {
+			continue
+		}
+		return Browser{
+			Family:  pattern.family,
+			Version: matchVersion(userAgent, pattern.version),
+			WebView: pattern.webView,
+		}
+	}
+	return Browser{}
+}
+
+func containsAny(text string, tokens []string) bool {
+	for _, token := range tokens {
+		if strings.Contains(text, token) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAll(text string, tokens []string) bool {
+	for _, token := range tokens {
+		if !strings.Contains(text, token) {
+			return false
+		}
+	}
+	return true
+}
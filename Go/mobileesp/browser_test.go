@@ -0,0 +1,50 @@
+package mobileesp
+
+import "testing"
+
+func TestBrowser(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      Browser
+	}{
+		{"Mozilla/5.0 (Linux; Android 13; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36", Browser{Family: BrowserChrome, Version: Version{114, 0, 5735}}},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/114.0.5735.124 Mobile/15E148 Safari/604.1", Browser{Family: BrowserChrome, Version: Version{114, 0, 5735}}},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Mobile/15E148 Safari/604.1", Browser{Family: BrowserSafari, Version: Version{16, 5, 0}}},
+		{"Mozilla/5.0 (Linux; Android 13; SAMSUNG SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/21.0 Chrome/110.0.5481.154 Mobile Safari/537.36", Browser{Family: BrowserSamsung, Version: Version{21, 0, 0}}},
+		{"Mozilla/5.0 (Linux; U; Android 10; en-US; RMX2020 Build/QP1A.190711.020) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/78.0.3904.108 UCBrowser/13.4.0.1306 Mobile Safari/537.36", Browser{Family: BrowserUC, Version: Version{13, 4, 0}}},
+		{"Mozilla/5.0 (Android 13; Mobile; rv:109.0) Gecko/114.0 Firefox/114.0", Browser{Family: BrowserFirefox, Version: Version{114, 0, 0}}},
+		{"Mozilla/5.0 (Linux; Android 10; HD1913) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36 EdgA/114.0.1823.67", Browser{Family: BrowserEdge, Version: Version{114, 0, 1823}}},
+		{"Mozilla/5.0 (Linux; Android 10; VOG-L29) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36 OPR/76.2.4027.73374", Browser{Family: BrowserOpera, Version: Version{76, 2, 4027}}},
+		{"Opera/9.80 (Android; Opera Mini/7.5.33361/31.1448; U; en) Presto/2.8.119 Version/11.1010", Browser{Family: BrowserOpera, Version: Version{7, 5, 33361}}},
+		{"Mozilla/5.0 (Linux; Android 12; SM-G991B Build/SP1A.210812.016; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/114.0.5735.196 Mobile Safari/537.36 [FB_IAB/FB4A;FBAV/420.0.0.32.61;]", Browser{Family: BrowserFacebook, Version: Version{420, 0, 0}, WebView: true}},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Instagram 289.0.0.25.109 (iPhone14,5; iOS 16_5; en_US; en; scale=3.00; 1170x2532; 489393226)", Browser{Family: BrowserInstagram, Version: Version{289, 0, 0}, WebView: true}},
+		{"Mozilla/5.0 (Linux; Android 12; Pixel 6 Build/SD1A.210817.023; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/94.0.4606.71 Mobile Safari/537.36", Browser{Family: BrowserWebView, Version: Version{94, 0, 4606}, WebView: true}},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148", Browser{Family: BrowserWebView, WebView: true}},
+		{"Mozilla/5.0 (Linux; U; Android 4.0.3; ko-kr; LG-L160L Build/IML74K) AppleWebkit/534.30 (KHTML, like Gecko) Version/4.0 Mobile Safari/534.30", Browser{Family: BrowserAndroid, Version: Version{4, 0, 0}}},
+		{"Mozilla/5.0 (compatible; MSIE 10.0; Windows Phone 8.0; Trident/6.0; IEMobile/10.0; ARM; Touch; NOKIA; Lumia 920)", Browser{Family: BrowserIE, Version: Version{10, 0, 0}}},
+		{"Mozilla/5.0 (Linux; U; Android 4.0.3; en-us; KFTT Build/IML74K) AppleWebKit/535.19 (KHTML, like Gecko) Silk/2.1 Mobile Safari/535.19 Silk-Accelerated=true", Browser{Family: BrowserSilk, Version: Version{2, 1, 0}}},
+		{"Roku/DVP-5.2 (025.02E03197A)", Browser{}},
+	}
+
+	for _, test := range tests {
+		if got := NewFromStrings(test.userAgent, "").Browser(); got != test.want {
+			t.Errorf("Browser() = %+v, want %+v\nUA: %s", got, test.want, test.userAgent)
+		}
+	}
+}
+
+func TestBrowserPrefersBrandHint(t *testing.T) {
+	const userAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36"
+	hints := ClientHints{Brands: []Brand{{"Not.A/Brand", "8"}, {"Chromium", "114"}, {"Microsoft Edge", "114"}}}
+
+	want := Browser{Family: BrowserEdge, Version: Version{114, 0, 0}}
+	if got := NewFromHints(userAgent, "", hints).Browser(); got != want {
+		t.Errorf("Browser() = %+v, want %+v", got, want)
+	}
+
+	hints.Brands[2] = Brand{"Google Chrome", "114"}
+	want = Browser{Family: BrowserChrome, Version: Version{114, 0, 0}}
+	if got := NewFromHints(userAgent, "", hints).Browser(); got != want {
+		t.Errorf("Browser() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,173 @@
+package mobileesp
+
+import (
+	"container/list"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The size of a Cache created with a size below 1.
+const DefaultCacheSize = 4096
+
+// Cache is a bounded, goroutine-safe LRU cache of detection results, keyed by
+// the User Agent, HTTP Accept and client hint values. Its constructors mirror
+// the package ones. A cached *UAgentInfo is shared between callers, so it is
+// fully evaluated before it is stored and must be treated as read-only.
+type Cache struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[cacheKey]*list.Element
+	order   *list.List //Most recently used first.
+	stats   CacheStats
+}
+
+// CacheStats counts the lookups of a Cache.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+type cacheKey struct {
+	userAgent  string
+	httpAccept string
+	hints      string
+}
+
+type cacheEntry struct {
+	key    cacheKey
+	detect *UAgentInfo
+}
+
+//**************************
+// Creates a cache holding up to size detections.
+func NewCache(size int) *Cache {
+	if size < 1 {
+		size = DefaultCacheSize
+	}
+	return &Cache{
+		size:    size,
+		entries: make(map[cacheKey]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+//**************************
+// Like NewMDetect, but returns the cached result for a known request.
+func (cache *Cache) NewMDetect(request *http.Request) *UAgentInfo {
+	return cache.NewFromHeader(request.Header)
+}
+
+//**************************
+// Like NewFromHeader, but returns the cached result for known headers.
+func (cache *Cache) NewFromHeader(header http.Header) *UAgentInfo {
+	uAgent, httpAccept := uAgentInfo(header)
+	return cache.NewFromHints(uAgent, httpAccept, ParseClientHints(header))
+}
+
+//**************************
+// Like NewFromStrings, but returns the cached result for known strings.
+func (cache *Cache) NewFromStrings(userAgent string, httpAccept string) *UAgentInfo {
+	return cache.NewFromHints(userAgent, httpAccept, ClientHints{})
+}
+
+//**************************
+// Like NewFromHints, but returns the cached result for known values.
+//   A result computed with rules that have since been replaced by
+//   RegisterRules or ResetRules counts as a miss.
+func (cache *Cache) NewFromHints(userAgent string, httpAccept string, hints ClientHints) *UAgentInfo {
+	key := cacheKey{userAgent: userAgent, httpAccept: httpAccept, hints: hints.cacheKey()}
+	rules := currentRules()
+
+	cache.mutex.Lock()
+	if element, ok := cache.entries[key]; ok {
+		entry := element.Value.(*cacheEntry)
+		if entry.detect.rules == rules {
+			cache.order.MoveToFront(element)
+			cache.stats.Hits++
+			cache.mutex.Unlock()
+			return entry.detect
+		}
+	}
+	cache.stats.Misses++
+	cache.mutex.Unlock()
+
+	//Detect outside the lock, so a slow scan doesn't hold up the hits.
+	detect := NewFromHints(userAgent, httpAccept, hints)
+	detect.evaluateAll()
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.entries[key]; ok {
+		element.Value.(*cacheEntry).detect = detect
+		cache.order.MoveToFront(element)
+		return detect
+	}
+	cache.entries[key] = cache.order.PushFront(&cacheEntry{key: key, detect: detect})
+	for cache.order.Len() > cache.size {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cacheEntry).key)
+		cache.stats.Evictions++
+	}
+	return detect
+}
+
+//**************************
+// Returns the hit, miss and eviction counts since the cache was created.
+func (cache *Cache) Stats() CacheStats {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.stats
+}
+
+//**************************
+// Returns the number of cached detections.
+func (cache *Cache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.order.Len()
+}
+
+//**************************
+// Removes every cached detection. The counters are kept.
+func (cache *Cache) Purge() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.entries = make(map[cacheKey]*list.Element, cache.size)
+	cache.order.Init()
+}
+
+// Joins the hint values into one comparable string.
+func (hints ClientHints) cacheKey() string {
+	if hints.Platform == "" && hints.PlatformVersion == "" && hints.Model == "" &&
+		len(hints.Brands) == 0 && !hints.MobileSent && !hints.TouchPointsSent {
+		return ""
+	}
+	var key strings.Builder
+	for _, brand := range hints.Brands {
+		key.WriteString(brand.Name)
+		key.WriteByte(0)
+		key.WriteString(brand.Version)
+		key.WriteByte(0)
+	}
+	switch {
+	case !hints.MobileSent:
+		key.WriteByte('-')
+	case hints.Mobile:
+		key.WriteByte('1')
+	default:
+		key.WriteByte('0')
+	}
+	for _, value := range []string{hints.Platform, hints.PlatformVersion, hints.Model} {
+		key.WriteByte(0)
+		key.WriteString(value)
+	}
+	if hints.TouchPointsSent {
+		key.WriteByte(0)
+		key.WriteString(strconv.Itoa(hints.TouchPoints))
+	}
+	return key.String()
+}
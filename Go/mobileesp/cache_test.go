@@ -0,0 +1,100 @@
+package mobileesp
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	cache := NewCache(2)
+	iphone, android, desktop := benchmarkUserAgents[0], benchmarkUserAgents[1], benchmarkUserAgents[3]
+
+	first := cache.NewFromStrings(iphone, "")
+	if second := cache.NewFromStrings(iphone, ""); second != first {
+		t.Error("NewFromStrings() rescanned a cached UA")
+	}
+	if !first.Device().TierIphone() {
+		t.Error("TierIphone() = false for a cached iPhone")
+	}
+	if cache.NewFromStrings(iphone, "text/html") == first {
+		t.Error("a different Accept value returned the cached result")
+	}
+	if cache.NewFromHints(iphone, "", ClientHints{Platform: "Android"}) == first {
+		t.Error("different client hints returned the cached result")
+	}
+
+	cache.NewFromStrings(android, "")
+	cache.NewFromStrings(desktop, "")
+	want := CacheStats{Hits: 1, Misses: 5, Evictions: 3}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if got := cache.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+
+	header := http.Header{}
+	header.Set("User-Agent", desktop)
+	if cache.NewFromHeader(header).Device().MobileQuick() {
+		t.Error("MobileQuick() = true for a cached desktop")
+	}
+	if got := cache.Stats().Hits; got != 2 {
+		t.Errorf("Hits = %d after NewFromHeader(), want 2", got)
+	}
+
+	cache.Purge()
+	if got := cache.Len(); got != 0 {
+		t.Errorf("Len() = %d after Purge(), want 0", got)
+	}
+}
+
+func TestCacheMissesAfterRegisterRules(t *testing.T) {
+	t.Cleanup(ResetRules)
+
+	cache := NewCache(0)
+	const userAgent = "FooPad/1.0"
+	if cache.NewFromStrings(userAgent, "").Device().Is("foopad") {
+		t.Fatal("Is(\"foopad\") = true before the rule was registered")
+	}
+	if err := RegisterRules(Rule{Name: "foopad", UserAgent: []string{"foopad"}}); err != nil {
+		t.Fatalf("RegisterRules() = %v", err)
+	}
+	if !cache.NewFromStrings(userAgent, "").Device().Is("foopad") {
+		t.Error("the cache returned a result from the replaced rules")
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	cache := NewCache(4)
+	var wait sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wait.Add(1)
+		go func(g int) {
+			defer wait.Done()
+			for i := 0; i < 200; i++ {
+				userAgent := benchmarkUserAgents[(g+i)%len(benchmarkUserAgents)]
+				device := cache.NewFromStrings(userAgent, "").Device()
+				if device.TierTablet() && device.MobileQuick() {
+					t.Errorf("tablet and phone at once for %q", userAgent)
+				}
+				device.Is("tieriphone")
+			}
+		}(g)
+	}
+	wait.Wait()
+
+	stats := cache.Stats()
+	if stats.Hits+stats.Misses != 8*200 {
+		t.Errorf("Stats() = %+v, want %d lookups", stats, 8*200)
+	}
+}
+
+func BenchmarkCache(b *testing.B) {
+	cache := NewCache(0)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			cache.NewFromStrings(benchmarkUserAgents[i%len(benchmarkUserAgents)], "")
+		}
+	})
+}
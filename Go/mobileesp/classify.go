@@ -0,0 +1,191 @@
+package mobileesp
+
+// Platform is the operating system family reported by Classify().
+type Platform string
+
+const (
+	PlatformUnknown       Platform = ""
+	PlatformIos           Platform = "ios"
+	PlatformAndroid       Platform = "android"
+	PlatformWindowsPhone  Platform = "windowsphone"
+	PlatformWindowsMobile Platform = "windowsmobile"
+	PlatformBlackBerry    Platform = "blackberry"
+	PlatformSymbian       Platform = "symbian"
+	PlatformWebOS         Platform = "webos"
+	PlatformPalmOS        Platform = "palmos"
+	PlatformBada          Platform = "bada"
+	PlatformTizen         Platform = "tizen"
+	PlatformMeego         Platform = "meego"
+	PlatformFirefoxOS     Platform = "firefoxos"
+	PlatformSailfish      Platform = "sailfish"
+	PlatformUbuntu        Platform = "ubuntu"
+	PlatformFireOS        Platform = "fireos"
+	PlatformHarmonyOS     Platform = "harmonyos"
+	PlatformKaiOS         Platform = "kaios"
+	PlatformChromeOS      Platform = "chromeos"
+	PlatformRoku          Platform = "roku"
+	PlatformTvOS          Platform = "tvos"
+	PlatformVidaa         Platform = "vidaa"
+)
+
+// FormFactor is the kind of hardware reported by Classify().
+type FormFactor string
+
+const (
+	FormFactorDesktop    FormFactor = "desktop"
+	FormFactorPhone      FormFactor = "phone"
+	FormFactorTablet     FormFactor = "tablet"
+	FormFactorTV         FormFactor = "tv"
+	FormFactorConsole    FormFactor = "console"
+	FormFactorEReader    FormFactor = "ereader"
+	FormFactorBot        FormFactor = "bot"
+	FormFactorWearable   FormFactor = "wearable"
+	FormFactorAutomotive FormFactor = "automotive"
+)
+
+// Tier is the MobileESP markup tier reported by Classify().
+type Tier string
+
+const (
+	TierNone    Tier = ""
+	TierTablet  Tier = "tablet"
+	TierTenFoot Tier = "tenfoot"
+	TierIphone  Tier = "iphone"
+	TierRichCss Tier = "richcss"
+	TierOther   Tier = "other"
+)
+
+// Engine is the browser engine reported by Classify().
+type Engine string
+
+const (
+	EngineUnknown Engine = ""
+	EngineWebKit  Engine = "webkit"
+	EngineOpera   Engine = "opera"
+)
+
+// DeviceInfo gathers the results of the detection methods into one value.
+type DeviceInfo struct {
+	Platform   Platform
+	FormFactor FormFactor
+	Tier       Tier
+	Engine     Engine
+	Mobile     bool //The result of DetectMobileQuick()
+}
+
+//**************************
+// Classifies the device in one call.
+//   Every field is derived from the Device() detection methods,
+//   so the results always match calling those methods one by one.
+func (base *UAgentInfo) Classify() DeviceInfo {
+	device := base.Device()
+	return DeviceInfo{
+		Platform:   device.platform(),
+		FormFactor: device.formFactor(),
+		Tier:       device.tier(),
+		Engine:     device.engine(),
+		Mobile:     device.MobileQuick(),
+	}
+}
+
+//**************************
+// Windows Phone is tested first because its UA also claims Android and iPhone.
+//   Fire OS and HarmonyOS are tested before Android, which they are based on.
+func (device Device) platform() Platform {
+	switch {
+	case device.WindowsPhone():
+		return PlatformWindowsPhone
+	case device.Ios():
+		return PlatformIos
+	case device.FireOS():
+		return PlatformFireOS
+	case device.HarmonyOS():
+		return PlatformHarmonyOS
+	case device.Android():
+		return PlatformAndroid
+	case device.WindowsMobile():
+		return PlatformWindowsMobile
+	case device.BlackBerry() || device.BlackBerryTablet():
+		return PlatformBlackBerry
+	case device.PalmWebOS() || device.WebOSTablet() || device.WebOSTV():
+		return PlatformWebOS
+	case device.PalmOS():
+		return PlatformPalmOS
+	case device.SymbianOS():
+		return PlatformSymbian
+	case device.Bada():
+		return PlatformBada
+	case device.Tizen() || device.TizenTV():
+		return PlatformTizen
+	case device.Meego():
+		return PlatformMeego
+	case device.Sailfish():
+		return PlatformSailfish
+	case device.Ubuntu():
+		return PlatformUbuntu
+	case device.KaiOS():
+		return PlatformKaiOS
+	case device.FirefoxOS():
+		return PlatformFirefoxOS
+	case device.ChromeOS():
+		return PlatformChromeOS
+	case device.RokuTV():
+		return PlatformRoku
+	case device.AppleTV():
+		return PlatformTvOS
+	case device.Vidaa():
+		return PlatformVidaa
+	}
+	return PlatformUnknown
+}
+
+//**************************
+// Bots are tested first, since many of them claim to be a phone.
+//   Consoles are tested before TVs, which include them.
+func (device Device) formFactor() FormFactor {
+	switch {
+	case device.Bot():
+		return FormFactorBot
+	case device.GameConsole():
+		return FormFactorConsole
+	case device.SmartTV():
+		return FormFactorTV
+	case device.Wearable():
+		return FormFactorWearable
+	case device.Automotive():
+		return FormFactorAutomotive
+	case device.TierTablet():
+		return FormFactorTablet
+	case device.Kindle():
+		return FormFactorEReader
+	case device.MobileLong():
+		return FormFactorPhone
+	}
+	return FormFactorDesktop
+}
+
+func (device Device) tier() Tier {
+	switch {
+	case device.TierTenFoot():
+		return TierTenFoot
+	case device.TierTablet():
+		return TierTablet
+	case device.TierIphone():
+		return TierIphone
+	case device.TierRichCss():
+		return TierRichCss
+	case device.TierOtherPhones():
+		return TierOther
+	}
+	return TierNone
+}
+
+func (device Device) engine() Engine {
+	switch {
+	case device.Webkit():
+		return EngineWebKit
+	case device.OperaMobile():
+		return EngineOpera
+	}
+	return EngineUnknown
+}
@@ -0,0 +1,41 @@
+package mobileesp
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      DeviceInfo
+	}{
+		{
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 8_3 like Mac OS X) AppleWebKit/600.1.4 (KHTML, like Gecko) Version/8.0 Mobile/12F70 Safari/600.1.4",
+			DeviceInfo{PlatformIos, FormFactorPhone, TierIphone, EngineWebKit, true},
+		},
+		{
+			"Mozilla/5.0 (iPad; U; CPU OS 4_3_1 like Mac OS X; en-us) AppleWebKit/533.17.9 (KHTML, like Gecko) Version/5.0.2 Mobile/8G4 Safari/6533.18.5",
+			DeviceInfo{PlatformIos, FormFactorTablet, TierTablet, EngineWebKit, false},
+		},
+		{
+			"Mozilla/5.0 (compatible; MSIE 10.0; Windows Phone 8.0; Trident/6.0; IEMobile/10.0; ARM; Touch; NOKIA; Lumia 920)",
+			DeviceInfo{PlatformWindowsPhone, FormFactorPhone, TierIphone, EngineUnknown, true},
+		},
+		{
+			"Mozilla/5.0 (Linux; U; en-US) AppleWebKit/528.5+ (KHTML, like Gecko, Safari/528.5+) Version/4.0 Kindle/3.0 (screen 600x800; rotate)",
+			DeviceInfo{PlatformUnknown, FormFactorEReader, TierOther, EngineWebKit, true},
+		},
+		{
+			"Mozilla/5.0 (PLAYSTATION 3; 1.00)",
+			DeviceInfo{PlatformUnknown, FormFactorConsole, TierTenFoot, EngineUnknown, false},
+		},
+		{
+			"Mozilla/5.0 (Windows NT 6.1; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/42.0.2311.135 Safari/537.36",
+			DeviceInfo{PlatformUnknown, FormFactorDesktop, TierNone, EngineWebKit, false},
+		},
+	}
+
+	for _, test := range tests {
+		if got := NewFromStrings(test.userAgent, "").Classify(); got != test.want {
+			t.Errorf("Classify() = %+v, want %+v\nUA: %s", got, test.want, test.userAgent)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package mobileesp
+
+import (
+	"net/http"
+	"strings"
+)
+
+// User-Agent Client Hint request headers read by NewFromHeader.
+const (
+	HeaderSecCHUA                = "Sec-CH-UA"
+	HeaderSecCHUAMobile          = "Sec-CH-UA-Mobile"
+	HeaderSecCHUAPlatform        = "Sec-CH-UA-Platform"
+	HeaderSecCHUAPlatformVersion = "Sec-CH-UA-Platform-Version"
+	HeaderSecCHUAModel           = "Sec-CH-UA-Model"
+)
+
+// The hints asked for by RequestClientHints.
+var clientHintHeaders = []string{
+	HeaderSecCHUAMobile,
+	HeaderSecCHUAPlatform,
+	HeaderSecCHUAPlatformVersion,
+	HeaderSecCHUAModel,
+}
+
+// Brand is one entry of the Sec-CH-UA brand list.
+type Brand struct {
+	Name    string
+	Version string
+}
+
+// ClientHints holds the User-Agent Client Hints sent with a request.
+// When present, they win over the User Agent string.
+type ClientHints struct {
+	Brands          []Brand
+	Mobile          bool //The value of Sec-CH-UA-Mobile. Only meaningful when MobileSent is true.
+	MobileSent      bool
+	Platform        string //For example "Android", "Windows" or "macOS".
+	PlatformVersion string
+	Model           string
+
+	//navigator.maxTouchPoints. No header carries it, so set it from a value
+	//  the page reports, such as a cookie, and pass the hints to NewFromHints.
+	//  Only meaningful when TouchPointsSent is true.
+	TouchPoints     int
+	TouchPointsSent bool
+}
+
+//**************************
+// Reads the Sec-CH-UA-* headers. Headers that weren't sent stay empty.
+func ParseClientHints(header http.Header) ClientHints {
+	hints := ClientHints{
+		Platform:        unquoteHint(header.Get(HeaderSecCHUAPlatform)),
+		PlatformVersion: unquoteHint(header.Get(HeaderSecCHUAPlatformVersion)),
+		Model:           unquoteHint(header.Get(HeaderSecCHUAModel)),
+	}
+
+	switch strings.TrimSpace(header.Get(HeaderSecCHUAMobile)) {
+	case "?1":
+		hints.Mobile, hints.MobileSent = true, true
+	case "?0":
+		hints.Mobile, hints.MobileSent = false, true
+	}
+
+	//For example: "Chromium";v="116", "Not)A;Brand";v="24", "Google Chrome";v="116"
+	for _, item := range splitHint(header.Get(HeaderSecCHUA), ',') {
+		parts := splitHint(item, ';')
+		brand := Brand{Name: unquoteHint(parts[0])}
+		if brand.Name == "" {
+			continue
+		}
+		for _, param := range parts[1:] {
+			if value := strings.TrimSpace(param); strings.HasPrefix(value, "v=") {
+				brand.Version = unquoteHint(value[2:])
+			}
+		}
+		hints.Brands = append(hints.Brands, brand)
+	}
+
+	return hints
+}
+
+//**************************
+// Splits a structured header value at sep, except inside RFC 8941 quoted
+//   strings. The GREASE brand of Chrome puts , and ; inside its name.
+func splitHint(value string, sep byte) []string {
+	var parts []string
+	quoted, start := false, 0
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case quoted && c == '\\':
+			i++ //Skips the escaped character.
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == sep:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+//**************************
+// Returns the contents of an RFC 8941 quoted string, with \" and \\ unescaped.
+//   Values that aren't quoted are returned trimmed.
+func unquoteHint(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return strings.Trim(value, `"`)
+	}
+
+	var unquoted strings.Builder
+	for i := 1; i < len(value)-1; i++ {
+		if value[i] == '\\' && i+1 < len(value)-1 {
+			i++
+		}
+		unquoted.WriteByte(value[i])
+	}
+	return unquoted.String()
+}
+
+//**************************
+// Reports whether a known platform was sent. "Unknown" doesn't count.
+func (hints ClientHints) hasPlatform() bool {
+	return hints.Platform != "" && !strings.EqualFold(hints.Platform, "unknown")
+}
+
+//**************************
+// Sets Accept-CH, so Chromium browsers send the hints on later requests,
+//   and Vary, so caches keep the variants apart. With critical set, also sets
+//   Critical-CH, which makes the browser retry the first request with the hints.
+func RequestClientHints(header http.Header, critical bool) {
+	names := strings.Join(clientHintHeaders, ", ")
+	header.Set("Accept-CH", names)
+	header.Add("Vary", names)
+	if critical {
+		header.Set("Critical-CH", names)
+	}
+}
+
+//**************************
+// Returns the client hints the detection was built from.
+func (base *UAgentInfo) ClientHints() ClientHints {
+	return base.clientHints
+}
+
+//**************************
+// Answers the token tests of a rule from the client hints, when they decide
+//   them. ok is false when the UA string has to be scanned instead.
+//   The rule's ExcludeRules and Requires still apply, so a watch or a TV
+//   that sends Sec-CH-UA-Mobile stays out of the phone and tablet rules.
+func (device Device) hinted(rule string) (result bool, ok bool) {
+	hints := device.info.clientHints
+	switch rule {
+	case "android":
+		if hints.hasPlatform() {
+			return strings.EqualFold(hints.Platform, "android"), true
+		}
+	case "androidphone":
+		if hints.MobileSent {
+			return hints.Mobile, true
+		}
+	case "androidtablet":
+		if hints.MobileSent {
+			return !hints.Mobile, true
+		}
+	case "mobilequick":
+		if hints.MobileSent {
+			return hints.Mobile, true
+		}
+	case "chromeos":
+		if hints.hasPlatform() {
+			return strings.EqualFold(hints.Platform, "chrome os"), true
+		}
+	case "touchscreen":
+		//Macs report 0, iPads report 5.
+		if hints.TouchPointsSent {
+			return hints.TouchPoints > 1, true
+		}
+	}
+	return false, false
+}
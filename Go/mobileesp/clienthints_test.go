@@ -0,0 +1,109 @@
+package mobileesp
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClientHintsWinOverUserAgent(t *testing.T) {
+	const frozen = "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Mobile Safari/537.36"
+	const desktopMode = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36"
+
+	header := http.Header{}
+	header.Set("User-Agent", frozen)
+	header.Set(HeaderSecCHUA, `"Chromium";v="118", "Google Chrome";v="118", "Not=A?Brand";v="99"`)
+	header.Set(HeaderSecCHUAMobile, "?0")
+	header.Set(HeaderSecCHUAPlatform, `"Android"`)
+	header.Set(HeaderSecCHUAPlatformVersion, `"13.0.0"`)
+	header.Set(HeaderSecCHUAModel, `"SM-X700"`)
+
+	detect := NewFromHeader(header)
+	device := detect.Device()
+	if device.AndroidPhone() || !device.AndroidTablet() || !device.TierTablet() || device.MobileQuick() {
+		t.Errorf("Sec-CH-UA-Mobile ?0 did not make a tablet: %+v", detect.Classify())
+	}
+	if got := detect.OSVersion(); got != (Version{13, 0, 0}) {
+		t.Errorf("OSVersion() = %v, want 13.0.0", got)
+	}
+
+	hints := detect.ClientHints()
+	if hints.Model != "SM-X700" || len(hints.Brands) != 3 || hints.Brands[1] != (Brand{"Google Chrome", "118"}) {
+		t.Errorf("ClientHints() = %+v", hints)
+	}
+
+	header.Set("User-Agent", desktopMode)
+	header.Set(HeaderSecCHUAMobile, "?1")
+	device = NewFromHeader(header).Device()
+	if !device.Android() || !device.AndroidPhone() || !device.TierIphone() || !device.MobileQuick() {
+		t.Errorf("Sec-CH-UA-Platform Android with ?1 did not make an Android phone")
+	}
+
+	header.Set(HeaderSecCHUAPlatform, `"Windows"`)
+	header.Set(HeaderSecCHUAMobile, "?0")
+	header.Set("User-Agent", frozen)
+	if NewFromHeader(header).Device().Android() {
+		t.Errorf("Sec-CH-UA-Platform Windows still detected Android")
+	}
+}
+
+func TestClientHintsKeepExclusions(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		mobile    bool
+		tier      Tier
+	}{
+		{"Wear OS watch", "Mozilla/5.0 (Linux; Android 13; Google Pixel Watch) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36", true, TierNone},
+		{"Android TV", "Mozilla/5.0 (Linux; Android 11; SHIELD Android TV Build/RQ1A.210105.003; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/114.0.5735.196 Safari/537.36", false, TierTenFoot},
+		{"Android Automotive", "Mozilla/5.0 (Linux; Android 10; Polestar 2 Build/QAAS.210531.001) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.164 Safari/537.36", false, TierNone},
+		{"Fire tablet", "Mozilla/5.0 (Linux; Android 9; KFMAWI) AppleWebKit/537.36 (KHTML, like Gecko) Silk/114.2.1 like Chrome/114.0.5735.196 Safari/537.36", true, TierTablet},
+	}
+
+	for _, test := range tests {
+		hints := ClientHints{Mobile: test.mobile, MobileSent: true, Platform: "Android"}
+		detect := NewFromHints(test.userAgent, "", hints)
+		device := detect.Device()
+		if device.AndroidPhone() || device.MobileQuick() || device.TierIphone() || device.TierRichCss() {
+			t.Errorf("%s: Sec-CH-UA-Mobile made a phone: %+v", test.name, detect.Classify())
+		}
+		if device.AndroidTablet() && test.tier != TierTablet {
+			t.Errorf("%s: AndroidTablet() = true", test.name)
+		}
+		if got := detect.Classify().Tier; got != test.tier {
+			t.Errorf("%s: Tier = %q, want %q", test.name, got, test.tier)
+		}
+	}
+}
+
+func TestParseClientHintsBrands(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []Brand
+	}{
+		//Chrome 116 sends a GREASE brand with a ; in its name.
+		{`"Chromium";v="116", "Not)A;Brand";v="24", "Google Chrome";v="116"`, []Brand{{"Chromium", "116"}, {"Not)A;Brand", "24"}, {"Google Chrome", "116"}}},
+		{`"Not_A Brand";v="8", "Chromium";v="120", "Microsoft Edge";v="120"`, []Brand{{"Not_A Brand", "8"}, {"Chromium", "120"}, {"Microsoft Edge", "120"}}},
+		{`"Not,A\"Brand";v="99", "Opera";v="105"`, []Brand{{`Not,A"Brand`, "99"}, {"Opera", "105"}}},
+	}
+
+	for _, test := range tests {
+		header := http.Header{}
+		header.Set(HeaderSecCHUA, test.value)
+		if got := ParseClientHints(header).Brands; !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Brands = %q, want %q\nSec-CH-UA: %s", got, test.want, test.value)
+		}
+	}
+}
+
+func TestRequestClientHints(t *testing.T) {
+	header := http.Header{}
+	RequestClientHints(header, true)
+	want := "Sec-CH-UA-Mobile, Sec-CH-UA-Platform, Sec-CH-UA-Platform-Version, Sec-CH-UA-Model"
+	if got := header.Get("Accept-CH"); got != want {
+		t.Errorf("Accept-CH = %q, want %q", got, want)
+	}
+	if got := header.Get("Critical-CH"); got != want {
+		t.Errorf("Critical-CH = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,282 @@
+// Command mobileesp-server serves the mobileesp detection as a JSON API, so
+// services in other languages get the same results as the Go ones.
+//
+// Usage:
+//
+//	mobileesp-server [flags]
+//
+// POST /classify takes one request object, or a list of them, and answers
+// with one result per request:
+//
+//	{"userAgent": "Mozilla/5.0 (iPhone; ...)", "accept": "text/html", "headers": {"Sec-CH-UA-Platform": "\"iOS\""}}
+//
+// "headers" is optional and holds any other request headers, such as the
+// User-Agent Client Hints. "userAgent" and "accept" win over the headers of
+// the same name. GET /healthz answers {"status": "ok"}.
+//
+// The server stops taking requests on SIGINT or SIGTERM and waits up to
+// -shutdown-timeout for the ones in flight.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fari-99/mobileesp/Go/mobileesp"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stderr))
+}
+
+type options struct {
+	maxBody  int64
+	maxBatch int
+	cache    *mobileesp.Cache
+}
+
+func run(ctx context.Context, args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("mobileesp-server", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	addr := flags.String("addr", ":8080", "the address to listen on")
+	maxBody := flags.Int64("max-body", 1<<20, "the largest request body accepted, in bytes")
+	maxBatch := flags.Int("max-batch", 1000, "the most requests accepted in one batch")
+	cacheSize := flags.Int("cache", mobileesp.DefaultCacheSize, "the number of detections to cache")
+	rulesFile := flags.String("rules", "", "a JSON or YAML rules file to add to the built-in rules")
+	devicesFile := flags.String("devices", "", "a JSON or YAML device file to add to the built-in device database")
+	shutdownTimeout := flags.Duration("shutdown-timeout", 10*time.Second, "how long to wait for requests in flight when stopping")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	logger := log.New(stderr, "mobileesp-server: ", log.LstdFlags)
+
+	if *rulesFile != "" {
+		if err := mobileesp.LoadRulesFile(*rulesFile); err != nil {
+			logger.Print(err)
+			return 1
+		}
+	}
+	if *devicesFile != "" {
+		if err := mobileesp.LoadDevicesFile(*devicesFile); err != nil {
+			logger.Print(err)
+			return 1
+		}
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           newHandler(options{maxBody: *maxBody, maxBatch: *maxBatch, cache: mobileesp.NewCache(*cacheSize)}),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+		ErrorLog:          logger,
+	}
+
+	failed := make(chan error, 1)
+	go func() {
+		logger.Printf("listening on %s", *addr)
+		failed <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-failed:
+		logger.Print(err)
+		return 1
+	case <-ctx.Done():
+	}
+
+	logger.Print("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Print(err)
+		return 1
+	}
+	return 0
+}
+
+func newHandler(opts options) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/classify", func(w http.ResponseWriter, r *http.Request) {
+		classify(w, r, opts)
+	})
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, http.StatusMethodNotAllowed, "use GET")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	return mux
+}
+
+// request is one User Agent to classify.
+type request struct {
+	UserAgent string            `json:"userAgent"`
+	Accept    string            `json:"accept,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// result is the detection of one request.
+type result struct {
+	UserAgent  string `json:"userAgent"`
+	Tier       string `json:"tier"`
+	Platform   string `json:"platform"`
+	FormFactor string `json:"formFactor"`
+	Engine     string `json:"engine"`
+	Mobile     bool   `json:"mobile"`
+	OSVersion  string `json:"osVersion"`
+
+	Browser struct {
+		Family  string `json:"family"`
+		Version string `json:"version"`
+		WebView bool   `json:"webView"`
+	} `json:"browser"`
+
+	Vendor string                      `json:"vendor"`
+	Model  string                      `json:"model"`
+	Device *mobileesp.DeviceProperties `json:"device,omitempty"` //From the device database, when it lists the model.
+
+	Detections map[string]bool `json:"detections"` //Every Detect*() method by name.
+}
+
+func classify(w http.ResponseWriter, r *http.Request, opts options) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "use POST")
+		return
+	}
+
+	//One byte past the limit tells an oversized body from one that fits exactly.
+	body, err := io.ReadAll(io.LimitReader(r.Body, opts.maxBody+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if int64(len(body)) > opts.maxBody {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("the body is larger than %d bytes", opts.maxBody))
+		return
+	}
+
+	body = bytes.TrimSpace(body)
+	batch := len(body) > 0 && body[0] == '['
+	var requests []request
+	if batch {
+		err = decodeStrict(body, &requests)
+	} else {
+		requests = make([]request, 1)
+		err = decodeStrict(body, &requests[0])
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if len(requests) > opts.maxBatch {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("a batch holds at most %d requests", opts.maxBatch))
+		return
+	}
+
+	results := make([]result, len(requests))
+	for i, req := range requests {
+		results[i] = detect(opts.cache, req)
+	}
+	if batch {
+		writeJSON(w, http.StatusOK, results)
+	} else {
+		writeJSON(w, http.StatusOK, results[0])
+	}
+}
+
+func decodeStrict(body []byte, into interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(into); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after the request")
+	}
+	return nil
+}
+
+func detect(cache *mobileesp.Cache, req request) result {
+	header := http.Header{}
+	for name, value := range req.Headers {
+		header.Set(name, value)
+	}
+	if req.UserAgent != "" {
+		header.Set("User-Agent", req.UserAgent)
+	}
+	if req.Accept != "" {
+		header.Set("Accept", req.Accept)
+	}
+	info := cache.NewFromHeader(header)
+
+	classified := info.Classify()
+	res := result{
+		UserAgent:  header.Get("User-Agent"),
+		Tier:       string(classified.Tier),
+		Platform:   string(classified.Platform),
+		FormFactor: string(classified.FormFactor),
+		Engine:     string(classified.Engine),
+		Mobile:     classified.Mobile,
+		OSVersion:  info.OSVersion().String(),
+		Vendor:     string(info.Vendor()),
+		Model:      info.Model(),
+		Detections: make(map[string]bool, len(detectMethods)),
+	}
+	browser := info.Browser()
+	res.Browser.Family = string(browser.Family)
+	res.Browser.Version = browser.Version.String()
+	res.Browser.WebView = browser.WebView
+	if properties, ok := info.Properties(); ok {
+		res.Device = &properties
+	}
+
+	value := reflect.ValueOf(info)
+	for _, method := range detectMethods {
+		res.Detections[method.Name] = method.Func.Call([]reflect.Value{value})[0].Int() == 1
+	}
+	return res
+}
+
+// The Detect*() methods of *UAgentInfo, which report 1 or 0.
+var detectMethods = func() []reflect.Method {
+	var methods []reflect.Method
+	kind := reflect.TypeOf(&mobileesp.UAgentInfo{})
+	for i := 0; i < kind.NumMethod(); i++ {
+		method := kind.Method(i)
+		if strings.HasPrefix(method.Name, "Detect") && method.Type.NumIn() == 1 &&
+			method.Type.NumOut() == 1 && method.Type.Out(0).Kind() == reflect.Int {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}()
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fari-99/mobileesp/Go/mobileesp"
+)
+
+const (
+	iphone = "Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Mobile/15E148 Safari/604.1"
+	galaxy = "Mozilla/5.0 (Linux; Android 13; SAMSUNG SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/21.0 Chrome/110.0.5481.154 Mobile Safari/537.36"
+)
+
+func testHandler() http.Handler {
+	return newHandler(options{maxBody: 4096, maxBatch: 2, cache: mobileesp.NewCache(0)})
+}
+
+func post(t *testing.T, handler http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/classify", strings.NewReader(body)))
+	return recorder
+}
+
+func TestClassifySingle(t *testing.T) {
+	recorder := post(t, testHandler(), `{"userAgent": "`+galaxy+`", "accept": "text/html"}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body)
+	}
+
+	var res result
+	if err := json.Unmarshal(recorder.Body.Bytes(), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Tier != "iphone" || res.Platform != "android" || res.FormFactor != "phone" || !res.Mobile {
+		t.Errorf("classification = %+v, want an iPhone-tier Android phone", res)
+	}
+	if res.Vendor != "samsung" || res.Model != "SM-S918B" || res.Device == nil || res.Device.Name != "Galaxy S23 Ultra" {
+		t.Errorf("vendor, model, device = %q, %q, %+v, want the Galaxy S23 Ultra", res.Vendor, res.Model, res.Device)
+	}
+	if res.Browser.Family != "samsung" || res.Browser.Version != "21.0.0" || res.OSVersion != "13.0.0" {
+		t.Errorf("browser, OS = %+v, %q, want Samsung Internet 21 on Android 13", res.Browser, res.OSVersion)
+	}
+	if !res.Detections["DetectAndroidPhone"] || res.Detections["DetectIphone"] {
+		t.Errorf("detections = %v, want DetectAndroidPhone only", res.Detections)
+	}
+}
+
+func TestClassifyBatchWithHeaders(t *testing.T) {
+	body := `[{"userAgent": "` + iphone + `"}, {"headers": {"User-Agent": "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Mobile Safari/537.36", "Sec-CH-UA-Model": "\"Pixel 7\""}}]`
+	recorder := post(t, testHandler(), body)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", recorder.Code, recorder.Body)
+	}
+
+	var results []result
+	if err := json.Unmarshal(recorder.Body.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Model != "iPhone" || results[1].Model != "Pixel 7" || results[1].Vendor != "google" {
+		t.Errorf("results = %+v, want the iPhone and the Pixel 7", results)
+	}
+}
+
+func TestClassifyRejects(t *testing.T) {
+	tests := []struct {
+		method string
+		body   string
+		status int
+	}{
+		{http.MethodGet, "", http.StatusMethodNotAllowed},
+		{http.MethodPost, "", http.StatusBadRequest},
+		{http.MethodPost, `{"userAgent": 1}`, http.StatusBadRequest},
+		{http.MethodPost, `{"agent": "x"}`, http.StatusBadRequest},
+		{http.MethodPost, `{"userAgent": "x"} {}`, http.StatusBadRequest},
+		{http.MethodPost, `[{}, {}, {}]`, http.StatusBadRequest},
+		{http.MethodPost, `{"userAgent": "` + strings.Repeat("x", 5000) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+
+	handler := testHandler()
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(test.method, "/classify", strings.NewReader(test.body)))
+		if recorder.Code != test.status {
+			t.Errorf("%s %.40q = %d, want %d", test.method, test.body, recorder.Code, test.status)
+		}
+		var answer map[string]string
+		if err := json.Unmarshal(recorder.Body.Bytes(), &answer); err != nil || answer["error"] == "" {
+			t.Errorf("%s %.40q answered %s, want a JSON error", test.method, test.body, recorder.Body)
+		}
+	}
+}
+
+func TestClassifyBodyLimit(t *testing.T) {
+	for size, status := range map[int]int{4096: http.StatusOK, 4097: http.StatusRequestEntityTooLarge} {
+		body := `{"userAgent": "` + strings.Repeat("x", size-len(`{"userAgent": ""}`)) + `"}`
+		recorder := httptest.NewRecorder()
+		testHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/classify", strings.NewReader(body)))
+		if recorder.Code != status {
+			t.Errorf("a %d byte body = %d, want %d", size, recorder.Code, status)
+		}
+	}
+}
+
+func TestHealth(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	testHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if recorder.Code != http.StatusOK || strings.TrimSpace(recorder.Body.String()) != `{"status":"ok"}` {
+		t.Errorf("GET /healthz = %d %s", recorder.Code, recorder.Body)
+	}
+}
+
+func TestRunShutsDown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var stderr bytes.Buffer
+	done := make(chan int, 1)
+	go func() {
+		done <- run(ctx, []string{"-addr", "127.0.0.1:0"}, &stderr)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	select {
+	case status := <-done:
+		if status != 0 {
+			t.Errorf("run() = %d, stderr = %s", status, stderr.String())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("run() did not return after the context was canceled")
+	}
+}
@@ -0,0 +1,301 @@
+// Command mobileesp classifies User Agent strings with the mobileesp package.
+//
+// Usage:
+//
+//	mobileesp [flags] [file ...]
+//
+// It reads the named files, or standard input when there are none. Each line
+// is a User Agent string, or with -log, a line of the Apache or Nginx combined
+// log format. For every line it prints the tier, platform and form factor,
+// followed by the selected Detect*() results and the User Agent:
+//
+//	mobileesp -detect DetectIphone,DetectAndroid < agents.txt
+//	mobileesp -log -summary /var/log/nginx/access.log
+//
+// With -summary it prints the number of lines per tier, platform and form
+// factor instead. With -explain it prints why each tier result was reached.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fari-99/mobileesp/Go/mobileesp"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+type options struct {
+	log     bool
+	summary bool
+	explain bool
+	accept  string
+	detect  []detectMethod
+}
+
+type detectMethod struct {
+	name   string
+	method reflect.Method
+}
+
+type summary struct {
+	lines     int
+	skipped   int
+	tiers     map[string]int
+	platforms map[string]int
+	forms     map[string]int
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("mobileesp", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var opts options
+	var detect string
+	flags.BoolVar(&opts.log, "log", false, "read the Apache/Nginx combined log format instead of one User Agent per line")
+	flags.BoolVar(&opts.summary, "summary", false, "print counts per tier, platform and form factor instead of one line per input")
+	flags.BoolVar(&opts.explain, "explain", false, "print the rules and tokens behind the tier results of each line")
+	flags.StringVar(&opts.accept, "accept", "", "the HTTP Accept value to classify with")
+	flags.StringVar(&detect, "detect", "", `comma-separated Detect*() methods to print, or "all"`)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "usage: mobileesp [flags] [file ...]\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var err error
+	if opts.detect, err = detectMethods(detect); err != nil {
+		fmt.Fprintf(stderr, "mobileesp: %v\n", err)
+		return 2
+	}
+
+	out := bufio.NewWriter(stdout)
+	defer out.Flush()
+
+	totals := &summary{tiers: map[string]int{}, platforms: map[string]int{}, forms: map[string]int{}}
+	cache := mobileesp.NewCache(0)
+	files := flags.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	status := 0
+	for _, name := range files {
+		if err := classifyFile(name, stdin, out, cache, opts, totals); err != nil {
+			fmt.Fprintf(stderr, "mobileesp: %v\n", err)
+			status = 1
+		}
+	}
+
+	if totals.skipped > 0 {
+		fmt.Fprintf(stderr, "mobileesp: skipped %d lines that are not in the combined log format\n", totals.skipped)
+	}
+	if opts.summary {
+		totals.write(out)
+	}
+	return status
+}
+
+func classifyFile(name string, stdin io.Reader, out *bufio.Writer, cache *mobileesp.Cache, opts options, totals *summary) error {
+	input := stdin
+	if name != "-" {
+		file, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		userAgent := line
+		if opts.log {
+			var ok bool
+			if userAgent, ok = logUserAgent(line); !ok {
+				totals.skipped++
+				continue
+			}
+		}
+
+		detect := cache.NewFromStrings(userAgent, opts.accept)
+		info := detect.Classify()
+		totals.lines++
+		totals.tiers[orNone(string(info.Tier))]++
+		totals.platforms[orNone(string(info.Platform))]++
+		totals.forms[string(info.FormFactor)]++
+		if opts.summary {
+			continue
+		}
+
+		fields := []string{orNone(string(info.Tier)), orNone(string(info.Platform)), string(info.FormFactor)}
+		for _, d := range opts.detect {
+			result := d.method.Func.Call([]reflect.Value{reflect.ValueOf(detect)})[0].Int()
+			fields = append(fields, fmt.Sprintf("%s=%d", d.name, result))
+		}
+		fields = append(fields, userAgent)
+		fmt.Fprintln(out, strings.Join(fields, "\t"))
+
+		if opts.explain {
+			for _, explanation := range detect.Explain() {
+				for _, text := range strings.SplitAfter(strings.TrimSuffix(explanation.String(), "\n"), "\n") {
+					fmt.Fprint(out, "  ", text)
+				}
+				fmt.Fprintln(out)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+//**************************
+// Resolves the -detect list to methods of *UAgentInfo. Names are matched
+//   case-insensitively, with or without the Detect prefix and parentheses.
+func detectMethods(list string) ([]detectMethod, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	var all []detectMethod
+	kind := reflect.TypeOf(&mobileesp.UAgentInfo{})
+	for i := 0; i < kind.NumMethod(); i++ {
+		method := kind.Method(i)
+		if strings.HasPrefix(method.Name, "Detect") && method.Type.NumIn() == 1 &&
+			method.Type.NumOut() == 1 && method.Type.Out(0).Kind() == reflect.Int {
+			all = append(all, detectMethod{name: method.Name, method: method})
+		}
+	}
+	if list == "all" {
+		return all, nil
+	}
+
+	var selected []detectMethod
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSuffix(strings.TrimSpace(name), "()")
+		if !strings.HasPrefix(strings.ToLower(name), "detect") {
+			name = "Detect" + name
+		}
+		found := false
+		for _, d := range all {
+			if strings.EqualFold(d.name, name) {
+				selected = append(selected, d)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown detection method %q", name)
+		}
+	}
+	return selected, nil
+}
+
+//**************************
+// Returns the User Agent of a combined log format line: the third quoted
+//   field, after the request line and the referrer. Nginx logs "-" when
+//   there was no User-Agent header.
+func logUserAgent(line string) (string, bool) {
+	fields, err := quotedFields(line)
+	if err != nil || len(fields) < 3 {
+		return "", false
+	}
+	if fields[2] == "-" {
+		return "", true
+	}
+	return fields[2], true
+}
+
+//**************************
+// Returns the quoted fields of a log line. Apache escapes a " in a field
+//   as \", and nginx as \x22, so both forms are decoded.
+func quotedFields(line string) ([]string, error) {
+	var fields []string
+	for {
+		start := strings.IndexByte(line, '"')
+		if start == -1 {
+			return fields, nil
+		}
+		line = line[start+1:]
+
+		var field strings.Builder
+		closed := false
+		for i := 0; i < len(line); i++ {
+			switch {
+			case line[i] == '\\' && i+3 < len(line) && line[i+1] == 'x':
+				if value, err := strconv.ParseUint(line[i+2:i+4], 16, 8); err == nil {
+					field.WriteByte(byte(value))
+					i += 3
+				} else {
+					i++
+					field.WriteByte(line[i])
+				}
+			case line[i] == '\\' && i+1 < len(line):
+				i++
+				field.WriteByte(line[i])
+			case line[i] == '"':
+				line = line[i+1:]
+				closed = true
+			default:
+				field.WriteByte(line[i])
+			}
+			if closed {
+				break
+			}
+		}
+		if !closed {
+			return fields, errors.New("unterminated quoted field")
+		}
+		fields = append(fields, field.String())
+	}
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+func (totals *summary) write(out io.Writer) {
+	fmt.Fprintf(out, "lines\t%d\n", totals.lines)
+	for _, section := range []struct {
+		title  string
+		counts map[string]int
+	}{
+		{"tier", totals.tiers},
+		{"platform", totals.platforms},
+		{"form factor", totals.forms},
+	} {
+		fmt.Fprintf(out, "\n%s\n", section.title)
+		names := make([]string, 0, len(section.counts))
+		for name := range section.counts {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			a, b := section.counts[names[i]], section.counts[names[j]]
+			return a > b || a == b && names[i] < names[j]
+		})
+		for _, name := range names {
+			count := section.counts[name]
+			fmt.Fprintf(out, "  %-12s\t%d\t%5.1f%%\n", name, count, 100*float64(count)/float64(totals.lines))
+		}
+	}
+}
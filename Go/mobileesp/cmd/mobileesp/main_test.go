@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	iphone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Mobile/15E148 Safari/604.1"
+	ipad    = "Mozilla/5.0 (iPad; U; CPU OS 4_3_1 like Mac OS X; en-us) AppleWebKit/533.17.9 (KHTML, like Gecko) Version/5.0.2 Mobile/8G4 Safari/6533.18.5"
+	desktop = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36"
+)
+
+func runCommand(t *testing.T, input string, args ...string) (string, string, int) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(input), &stdout, &stderr)
+	return stdout.String(), stderr.String(), status
+}
+
+func TestRunPrintsOneLinePerUserAgent(t *testing.T) {
+	stdout, stderr, status := runCommand(t, iphone+"\n\n"+desktop+"\n", "-detect", "iphone,DetectTierTablet()")
+	if status != 0 {
+		t.Fatalf("status = %d, stderr = %q", status, stderr)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	want := []string{
+		"iphone\tios\tphone\tDetectIphone=1\tDetectTierTablet=0\t" + iphone,
+		"none\tnone\tdesktop\tDetectIphone=0\tDetectTierTablet=0\t" + desktop,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), stdout)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestRunDetectAll(t *testing.T) {
+	stdout, _, _ := runCommand(t, ipad+"\n", "-detect", "all")
+	for _, field := range []string{"DetectIpad=1", "DetectTierTablet=1", "DetectIphone=0", "DetectMobileQuick=0"} {
+		if !strings.Contains(stdout, "\t"+field+"\t") {
+			t.Errorf("output is missing %s:\n%s", field, stdout)
+		}
+	}
+}
+
+func TestRunUnknownMethod(t *testing.T) {
+	_, stderr, status := runCommand(t, "", "-detect", "DetectNothing")
+	if status != 2 || !strings.Contains(stderr, `"DetectNothing"`) {
+		t.Errorf("status = %d, stderr = %q, want 2 naming the method", status, stderr)
+	}
+}
+
+func TestRunCombinedLogSummary(t *testing.T) {
+	log := strings.Join([]string{
+		`127.0.0.1 - - [10/Oct/2023:13:55:36 +0000] "GET / HTTP/1.1" 200 2326 "-" "` + iphone + `"`,
+		`127.0.0.1 - - [10/Oct/2023:13:55:37 +0000] "GET /a HTTP/1.1" 200 512 "https://example.com/" "` + iphone + `"`,
+		`127.0.0.1 - frank [10/Oct/2023:13:55:38 +0000] "GET /b HTTP/1.1" 304 0 "-" "` + ipad + `"`,
+		`127.0.0.1 - - [10/Oct/2023:13:55:39 +0000] "GET /c HTTP/1.1" 200 10 "-" "-"`,
+		`not a log line`,
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "access.log")
+	if err := os.WriteFile(path, []byte(log), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, stderr, status := runCommand(t, "", "-log", "-summary", path)
+	if status != 0 {
+		t.Fatalf("status = %d, stderr = %q", status, stderr)
+	}
+	if !strings.Contains(stderr, "skipped 1 lines") {
+		t.Errorf("stderr = %q, want the skipped line count", stderr)
+	}
+	for _, want := range []string{"lines\t4\n", "  iphone      \t2\t 50.0%\n", "  tablet      \t1\t 25.0%\n", "  ios         \t3\t 75.0%\n", "  none        \t1\t 25.0%\n"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("summary is missing %q:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestRunExplain(t *testing.T) {
+	stdout, _, _ := runCommand(t, ipad+"\n", "-explain")
+	if !strings.Contains(stdout, `  mobilequick = false; excluded by "tiertablet"`) {
+		t.Errorf("output is missing the MobileQuick explanation:\n%s", stdout)
+	}
+}
+
+func TestLogUserAgent(t *testing.T) {
+	tests := []struct {
+		line, want string
+		ok         bool
+	}{
+		{`1.2.3.4 - - [x] "GET / HTTP/1.1" 200 1 "-" "Agent \"quoted\" 1.0"`, `Agent "quoted" 1.0`, true},
+		{`1.2.3.4 - - [x] "GET / HTTP/1.1" 200 1 "-" "Agent \x22quoted\x22 1.0"`, `Agent "quoted" 1.0`, true},
+		{`1.2.3.4 - - [x] "GET / HTTP/1.1" 200 1 "-" "Agent \xZZ 1.0"`, `Agent xZZ 1.0`, true},
+		{`1.2.3.4 - - [x] "GET / HTTP/1.1" 200 1 "-" "-"`, "", true},
+		{`1.2.3.4 - - [x] "GET / HTTP/1.1" 200 1 "-"`, "", false},
+		{`1.2.3.4 - - [x] "GET / HTTP/1.1" 200 1 "-" "unterminated`, "", false},
+	}
+	for _, test := range tests {
+		got, ok := logUserAgent(test.line)
+		if got != test.want || ok != test.ok {
+			t.Errorf("logUserAgent(%q) = %q, %v, want %q, %v", test.line, got, ok, test.want, test.ok)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package mobileesp
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The golden corpus is the UA spreadsheet shared by every MobileESP port.
+// Each row lists the Detect*() methods expected to return 1 for its UA string,
+// separated by commas or line breaks. Prefix a method with "!" to expect 0 instead.
+const corpusPath = "../../MobileESP_UA-Test-Strings/MobileESP UA Test Strings - UA Strings.csv"
+
+// The expectations TestCorpus skips, with the reason for each. A row with an
+// empty "UA String" skips the method for every UA: methods this port doesn't
+// implement, and notes in the methods column such as "Unsupported". Any other
+// name that doesn't resolve to a Detect*() method fails its row, so adding a
+// corpus row or an exception needs no Go changes.
+const corpusExceptionsPath = "testdata/corpus_exceptions.csv"
+
+type corpusException struct {
+	userAgent string
+	method    string
+}
+
+type corpusRow struct {
+	line      int
+	device    string
+	userAgent string
+	methods   []string
+}
+
+func loadCorpus(t *testing.T) []corpusRow {
+	t.Helper()
+
+	file, err := os.Open(corpusPath)
+	if err != nil {
+		t.Fatalf("open corpus: %v", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read corpus: %v", err)
+	}
+	if len(records) == 0 {
+		t.Fatalf("corpus %q is empty", corpusPath)
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{"Device Name", "UA String", "MobileESP Detection Methods"} {
+		if _, ok := columns[name]; !ok {
+			t.Fatalf("corpus is missing the %q column", name)
+		}
+	}
+
+	var rows []corpusRow
+	for i, record := range records[1:] {
+		row := corpusRow{
+			line:      i + 2,
+			device:    strings.TrimSpace(record[columns["Device Name"]]),
+			userAgent: strings.TrimSpace(record[columns["UA String"]]),
+		}
+		cell := record[columns["MobileESP Detection Methods"]]
+		for _, method := range strings.FieldsFunc(cell, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' }) {
+			method = strings.TrimSuffix(strings.TrimSpace(method), "()")
+			if method != "" {
+				row.methods = append(row.methods, method)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func loadCorpusExceptions(t *testing.T) map[corpusException]string {
+	t.Helper()
+
+	file, err := os.Open(corpusExceptionsPath)
+	if err != nil {
+		t.Fatalf("open corpus exceptions: %v", err)
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("read corpus exceptions: %v", err)
+	}
+	if len(records) == 0 || len(records[0]) != 3 || records[0][0] != "UA String" || records[0][1] != "Method" || records[0][2] != "Reason" {
+		t.Fatalf("corpus exceptions must have the columns \"UA String\", \"Method\" and \"Reason\"")
+	}
+
+	exceptions := map[corpusException]string{}
+	for i, record := range records[1:] {
+		if strings.TrimSpace(record[1]) == "" || strings.TrimSpace(record[2]) == "" {
+			t.Fatalf("corpus exceptions line %d: the method and reason are required", i+2)
+		}
+		key := corpusException{strings.TrimSpace(record[0]), strings.ToLower(strings.TrimSpace(record[1]))}
+		exceptions[key] = strings.TrimSpace(record[2])
+	}
+	return exceptions
+}
+
+// Returns the reason to skip method for userAgent, if it is an exception.
+func corpusSkip(exceptions map[corpusException]string, userAgent string, method string) (string, bool) {
+	method = strings.ToLower(method)
+	if reason, ok := exceptions[corpusException{userAgent, method}]; ok {
+		return reason, true
+	}
+	reason, ok := exceptions[corpusException{"", method}]
+	return reason, ok
+}
+
+// Looks up a Detect*() method by name. The spreadsheet is shared with the
+// other ports, so names are matched case-insensitively (DetectWebKit).
+func corpusMethod(detect *UAgentInfo, name string) (reflect.Value, bool) {
+	value := reflect.ValueOf(detect)
+	kind := value.Type()
+	for i := 0; i < kind.NumMethod(); i++ {
+		method := kind.Method(i)
+		if strings.EqualFold(method.Name, name) && strings.HasPrefix(method.Name, "Detect") {
+			return value.Method(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func TestCorpus(t *testing.T) {
+	exceptions := loadCorpusExceptions(t)
+	for _, row := range loadCorpus(t) {
+		row := row
+		t.Run(fmt.Sprintf("line%d/%s", row.line, row.device), func(t *testing.T) {
+			if !strings.Contains(row.userAgent, "/") {
+				t.Skipf("no UA string: %q", row.userAgent)
+			}
+
+			detect := NewFromStrings(row.userAgent, "")
+			for _, name := range row.methods {
+				want := 1
+				if strings.HasPrefix(name, "!") {
+					want = 0
+					name = name[1:]
+				}
+
+				if reason, ok := corpusSkip(exceptions, row.userAgent, name); ok {
+					t.Logf("line %d: skipping %s: %s", row.line, name, reason)
+					continue
+				}
+				method, ok := corpusMethod(detect, name)
+				if !ok {
+					t.Errorf("line %d: %q is not a Detect*() method; fix the name or list it in %s", row.line, name, corpusExceptionsPath)
+					continue
+				}
+				if got := method.Call(nil)[0].Interface(); got != want {
+					t.Errorf("line %d: %s() = %v, want %d\nUA: %s", row.line, name, got, want, row.userAgent)
+				}
+			}
+		})
+	}
+}
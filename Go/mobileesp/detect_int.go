@@ -0,0 +1,767 @@
+package mobileesp
+
+//**************************
+// The int-returning Detect*() methods predate Device(). They return 1 for true,
+//   or 0 for false, and are kept so existing callers keep compiling.
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+//**************************
+// Detects if the current device is an iPhone.
+//
+// Deprecated: Use Device().Iphone() instead.
+func (base *UAgentInfo) DetectIphone() int {
+	return boolToInt(base.Device().Iphone())
+}
+
+//**************************
+// Detects if the current device is an iPod Touch.
+//
+// Deprecated: Use Device().Ipod() instead.
+func (base *UAgentInfo) DetectIpod() int {
+	return boolToInt(base.Device().Ipod())
+}
+
+//**************************
+// Detects if the current device is an iPad tablet.
+//
+// Deprecated: Use Device().Ipad() instead.
+func (base *UAgentInfo) DetectIpad() int {
+	return boolToInt(base.Device().Ipad())
+}
+
+//**************************
+// Detects an iPad whose Safari asks for desktop sites, as iPadOS 13+ does by default.
+//   Its UA is the one of Mac Safari, so this needs the TouchPoints client hint.
+//   DetectIpad() includes it.
+//
+// Deprecated: Use Device().IpadDesktopMode() instead.
+func (base *UAgentInfo) DetectIpadDesktopMode() int {
+	return boolToInt(base.Device().IpadDesktopMode())
+}
+
+//**************************
+// Detects if the current device is an iPhone or iPod Touch.
+//
+// Deprecated: Use Device().IphoneOrIpod() instead.
+func (base *UAgentInfo) DetectIphoneOrIpod() int {
+	return boolToInt(base.Device().IphoneOrIpod())
+}
+
+//**************************
+// Detects *any* iOS device: iPhone, iPod Touch, iPad.
+//
+// Deprecated: Use Device().Ios() instead.
+func (base *UAgentInfo) DetectIos() int {
+	return boolToInt(base.Device().Ios())
+}
+
+//**************************
+// Detects *any* Android OS-based device: phone, tablet, and multi-media player.
+// Also detects Google TV.
+//
+// Deprecated: Use Device().Android() instead.
+func (base *UAgentInfo) DetectAndroid() int {
+	return boolToInt(base.Device().Android())
+}
+
+//**************************
+// Detects if the current device is a (small-ish) Android OS-based device
+// used for calling and/or multi-media (like a Samsung Galaxy Player).
+// Google says these devices will have 'Android' AND 'mobile' in user agent.
+// Ignores tablets (Honeycomb and later).
+//
+// Deprecated: Use Device().AndroidPhone() instead.
+func (base *UAgentInfo) DetectAndroidPhone() int {
+	return boolToInt(base.Device().AndroidPhone())
+}
+
+//**************************
+// Detects if the current device is a (self-reported) Android tablet.
+// Google says these devices will have 'Android' and NOT 'mobile' in their user agent.
+//
+// Deprecated: Use Device().AndroidTablet() instead.
+func (base *UAgentInfo) DetectAndroidTablet() int {
+	return boolToInt(base.Device().AndroidTablet())
+}
+
+//**************************
+// Detects if the current device is an Android OS-based device and
+//   the browser is based on WebKit.
+//
+// Deprecated: Use Device().AndroidWebKit() instead.
+func (base *UAgentInfo) DetectAndroidWebKit() int {
+	return boolToInt(base.Device().AndroidWebKit())
+}
+
+//**************************
+// Detects if the current device is a GoogleTV.
+//
+// Deprecated: Use Device().GoogleTV() instead.
+func (base *UAgentInfo) DetectGoogleTV() int {
+	return boolToInt(base.Device().GoogleTV())
+}
+
+//**************************
+// Detects a smart TV, streaming stick, set-top box or game console:
+//   anything used from the couch. Handheld consoles are left out.
+//
+// Deprecated: Use Device().SmartTV() instead.
+func (base *UAgentInfo) DetectSmartTV() int {
+	return boolToInt(base.Device().SmartTV())
+}
+
+//**************************
+// Detects an Android TV or Google TV device, such as the Nvidia Shield or a Sony Bravia.
+//
+// Deprecated: Use Device().AndroidTV() instead.
+func (base *UAgentInfo) DetectAndroidTV() int {
+	return boolToInt(base.Device().AndroidTV())
+}
+
+//**************************
+// Detects an Amazon Fire TV. It has no browser, only WebViews in apps.
+//
+// Deprecated: Use Device().FireTV() instead.
+func (base *UAgentInfo) DetectFireTV() int {
+	return boolToInt(base.Device().FireTV())
+}
+
+//**************************
+// Detects a Roku player or TV. It has no browser, only WebViews in apps.
+//
+// Deprecated: Use Device().RokuTV() instead.
+func (base *UAgentInfo) DetectRokuTV() int {
+	return boolToInt(base.Device().RokuTV())
+}
+
+//**************************
+// Detects an Apple TV.
+//
+// Deprecated: Use Device().AppleTV() instead.
+func (base *UAgentInfo) DetectAppleTV() int {
+	return boolToInt(base.Device().AppleTV())
+}
+
+//**************************
+// Detects a Chromecast, including Chromecast with Google TV.
+//
+// Deprecated: Use Device().Chromecast() instead.
+func (base *UAgentInfo) DetectChromecast() int {
+	return boolToInt(base.Device().Chromecast())
+}
+
+//**************************
+// Detects a TV that runs HbbTV, the European hybrid broadcast standard.
+//
+// Deprecated: Use Device().HbbTV() instead.
+func (base *UAgentInfo) DetectHbbTV() int {
+	return boolToInt(base.Device().HbbTV())
+}
+
+//**************************
+// Detects a Hisense TV running Vidaa OS.
+//
+// Deprecated: Use Device().Vidaa() instead.
+func (base *UAgentInfo) DetectVidaa() int {
+	return boolToInt(base.Device().Vidaa())
+}
+
+//**************************
+// Detects a smartwatch: Wear OS, Apple Watch or a Tizen Galaxy watch.
+//   Watches are not in the phone tiers or DetectMobileQuick().
+//
+// Deprecated: Use Device().Wearable() instead.
+func (base *UAgentInfo) DetectWearable() int {
+	return boolToInt(base.Device().Wearable())
+}
+
+//**************************
+// Detects the browser of a car head unit, such as Android Automotive or a Tesla.
+//   Cars are not in the phone or tablet tiers or DetectMobileQuick().
+//
+// Deprecated: Use Device().Automotive() instead.
+func (base *UAgentInfo) DetectAutomotive() int {
+	return boolToInt(base.Device().Automotive())
+}
+
+//**************************
+// Detects if the current browser is based on WebKit.
+//
+// Deprecated: Use Device().Webkit() instead.
+func (base *UAgentInfo) DetectWebkit() int {
+	return boolToInt(base.Device().Webkit())
+}
+
+//**************************
+// Detects if the current browser is a
+// Windows Phone 7, 8, or 10 device.
+//
+// Deprecated: Use Device().WindowsPhone() instead.
+func (base *UAgentInfo) DetectWindowsPhone() int {
+	return boolToInt(base.Device().WindowsPhone())
+}
+
+//**************************
+// Detects a Windows Phone 7 device (in mobile browsing mode).
+//
+// Deprecated: Use Device().WindowsPhone7() instead.
+func (base *UAgentInfo) DetectWindowsPhone7() int {
+	return boolToInt(base.Device().WindowsPhone7())
+}
+
+//**************************
+// Detects a Windows Phone 8 device (in mobile browsing mode).
+//
+// Deprecated: Use Device().WindowsPhone8() instead.
+func (base *UAgentInfo) DetectWindowsPhone8() int {
+	return boolToInt(base.Device().WindowsPhone8())
+}
+
+//**************************
+// Detects a Windows Phone 10 device (in mobile browsing mode).
+//
+// Deprecated: Use Device().WindowsPhone10() instead.
+func (base *UAgentInfo) DetectWindowsPhone10() int {
+	return boolToInt(base.Device().WindowsPhone10())
+}
+
+//**************************
+// Detects if the current browser is a Windows Mobile device.
+// Excludes Windows Phone 7 and later devices.
+// Focuses on Windows Mobile 6.xx and earlier.
+//
+// Deprecated: Use Device().WindowsMobile() instead.
+func (base *UAgentInfo) DetectWindowsMobile() int {
+	return boolToInt(base.Device().WindowsMobile())
+}
+
+//**************************
+// Detects if the current browser is any BlackBerry device.
+// Includes BB10 OS, but excludes the PlayBook.
+//
+// Deprecated: Use Device().BlackBerry() instead.
+func (base *UAgentInfo) DetectBlackBerry() int {
+	return boolToInt(base.Device().BlackBerry())
+}
+
+//**************************
+// Detects if the current browser is a BlackBerry 10 OS phone.
+// Excludes tablets.
+//
+// Deprecated: Use Device().BlackBerry10Phone() instead.
+func (base *UAgentInfo) DetectBlackBerry10Phone() int {
+	return boolToInt(base.Device().BlackBerry10Phone())
+}
+
+//**************************
+// Detects if the current browser is on a BlackBerry tablet device.
+//    Examples: PlayBook
+//
+// Deprecated: Use Device().BlackBerryTablet() instead.
+func (base *UAgentInfo) DetectBlackBerryTablet() int {
+	return boolToInt(base.Device().BlackBerryTablet())
+}
+
+//**************************
+// Detects if the current browser is a BlackBerry phone device AND uses a
+//    WebKit-based browser. These are signatures for the new BlackBerry OS 6.
+//    Examples: Torch. Includes the Playbook.
+//
+// Deprecated: Use Device().BlackBerryWebKit() instead.
+func (base *UAgentInfo) DetectBlackBerryWebKit() int {
+	return boolToInt(base.Device().BlackBerryWebKit())
+}
+
+//**************************
+// Detects if the current browser is a BlackBerry Touch phone device with
+//    a large screen, such as the Storm, Torch, and Bold Touch. Excludes the Playbook.
+//
+// Deprecated: Use Device().BlackBerryTouch() instead.
+func (base *UAgentInfo) DetectBlackBerryTouch() int {
+	return boolToInt(base.Device().BlackBerryTouch())
+}
+
+//**************************
+// Detects if the current browser is a BlackBerry OS 5 device AND
+//    has a more capable recent browser. Excludes the Playbook.
+//    Examples, Storm, Bold, Tour, Curve2
+//    Excludes the new BlackBerry OS 6 and 7 browser!!
+//
+// Deprecated: Use Device().BlackBerryHigh() instead.
+func (base *UAgentInfo) DetectBlackBerryHigh() int {
+	return boolToInt(base.Device().BlackBerryHigh())
+}
+
+//**************************
+// Detects if the current browser is a BlackBerry device AND
+//    has an older, less capable browser.
+//    Examples: Pearl, 8800, Curve1.
+//
+// Deprecated: Use Device().BlackBerryLow() instead.
+func (base *UAgentInfo) DetectBlackBerryLow() int {
+	return boolToInt(base.Device().BlackBerryLow())
+}
+
+//**************************
+// Detects if the current browser is the Nokia S60 Open Source Browser.
+//
+// Deprecated: Use Device().S60OssBrowser() instead.
+func (base *UAgentInfo) DetectS60OssBrowser() int {
+	return boolToInt(base.Device().S60OssBrowser())
+}
+
+//**************************
+// Detects if the current device is any Symbian OS-based device,
+//   including older S60, Series 70, Series 80, Series 90, and UIQ,
+//   or other browsers running on these devices.
+//
+// Deprecated: Use Device().SymbianOS() instead.
+func (base *UAgentInfo) DetectSymbianOS() int {
+	return boolToInt(base.Device().SymbianOS())
+}
+
+//**************************
+// Detects if the current browser is on a PalmOS device.
+//
+// Deprecated: Use Device().PalmOS() instead.
+func (base *UAgentInfo) DetectPalmOS() int {
+	return boolToInt(base.Device().PalmOS())
+}
+
+//**************************
+// Detects if the current browser is on a Palm device
+//   running the new WebOS.
+//
+// Deprecated: Use Device().PalmWebOS() instead.
+func (base *UAgentInfo) DetectPalmWebOS() int {
+	return boolToInt(base.Device().PalmWebOS())
+}
+
+//**************************
+// Detects if the current browser is on an HP tablet running WebOS.
+//
+// Deprecated: Use Device().WebOSTablet() instead.
+func (base *UAgentInfo) DetectWebOSTablet() int {
+	return boolToInt(base.Device().WebOSTablet())
+}
+
+//**************************
+// Detects if the current browser is on a WebOS smart TV.
+//
+// Deprecated: Use Device().WebOSTV() instead.
+func (base *UAgentInfo) DetectWebOSTV() int {
+	return boolToInt(base.Device().WebOSTV())
+}
+
+//**************************
+// Detects if the current browser is Opera Mobile or Mini.
+//
+// Deprecated: Use Device().OperaMobile() instead.
+func (base *UAgentInfo) DetectOperaMobile() int {
+	return boolToInt(base.Device().OperaMobile())
+}
+
+//**************************
+// Detects if the current device is an Amazon Kindle (eInk devices only).
+// Note: For the Kindle Fire, use DetectFireTablet() or the normal Android methods.
+//
+// Deprecated: Use Device().Kindle() instead.
+func (base *UAgentInfo) DetectKindle() int {
+	return boolToInt(base.Device().Kindle())
+}
+
+//**************************
+// Detects if the current Amazon device has turned on the Silk accelerated browsing feature.
+// Note: Typically used by the the Kindle Fire.
+//
+// Deprecated: Use Device().AmazonSilk() instead.
+func (base *UAgentInfo) DetectAmazonSilk() int {
+	return boolToInt(base.Device().AmazonSilk())
+}
+
+//**************************
+// Detects Amazon Fire OS, which is based on Android: Fire tablets and the Fire Phone.
+//
+// Deprecated: Use Device().FireOS() instead.
+func (base *UAgentInfo) DetectFireOS() int {
+	return boolToInt(base.Device().FireOS())
+}
+
+//**************************
+// Detects an Amazon Fire tablet, including the Kindle Fire.
+//   Silk sends "Mobile" on them too, so they don't pass DetectAndroidTablet().
+//
+// Deprecated: Use Device().FireTablet() instead.
+func (base *UAgentInfo) DetectFireTablet() int {
+	return boolToInt(base.Device().FireTablet())
+}
+
+//**************************
+// Detects if a Garmin Nuvifone device.
+//
+// Deprecated: Use Device().GarminNuvifone() instead.
+func (base *UAgentInfo) DetectGarminNuvifone() int {
+	return boolToInt(base.Device().GarminNuvifone())
+}
+
+//**************************
+// Detects a device running the Bada OS from Samsung.
+//
+// Deprecated: Use Device().Bada() instead.
+func (base *UAgentInfo) DetectBada() int {
+	return boolToInt(base.Device().Bada())
+}
+
+//**************************
+// Detects a device running the Tizen smartphone OS.
+//
+// Deprecated: Use Device().Tizen() instead.
+func (base *UAgentInfo) DetectTizen() int {
+	return boolToInt(base.Device().Tizen())
+}
+
+//**************************
+// Detects if the current browser is on a Tizen smart TV.
+//
+// Deprecated: Use Device().TizenTV() instead.
+func (base *UAgentInfo) DetectTizenTV() int {
+	return boolToInt(base.Device().TizenTV())
+}
+
+//**************************
+// Detects a device running the Meego OS.
+//
+// Deprecated: Use Device().Meego() instead.
+func (base *UAgentInfo) DetectMeego() int {
+	return boolToInt(base.Device().Meego())
+}
+
+//**************************
+// Detects a phone running the Meego OS.
+//
+// Deprecated: Use Device().MeegoPhone() instead.
+func (base *UAgentInfo) DetectMeegoPhone() int {
+	return boolToInt(base.Device().MeegoPhone())
+}
+
+//**************************
+// Detects a mobile device (probably) running the Firefox OS.
+//
+// Deprecated: Use Device().FirefoxOS() instead.
+func (base *UAgentInfo) DetectFirefoxOS() int {
+	return boolToInt(base.Device().FirefoxOS())
+}
+
+//**************************
+// Detects a phone (probably) running the Firefox OS.
+//
+// Deprecated: Use Device().FirefoxOSPhone() instead.
+func (base *UAgentInfo) DetectFirefoxOSPhone() int {
+	return boolToInt(base.Device().FirefoxOSPhone())
+}
+
+//**************************
+// Detects a tablet (probably) running the Firefox OS.
+//
+// Deprecated: Use Device().FirefoxOSTablet() instead.
+func (base *UAgentInfo) DetectFirefoxOSTablet() int {
+	return boolToInt(base.Device().FirefoxOSTablet())
+}
+
+//**************************
+// Detects a device running the Sailfish OS.
+//
+// Deprecated: Use Device().Sailfish() instead.
+func (base *UAgentInfo) DetectSailfish() int {
+	return boolToInt(base.Device().Sailfish())
+}
+
+//**************************
+// Detects a phone running the Sailfish OS.
+//
+// Deprecated: Use Device().SailfishPhone() instead.
+func (base *UAgentInfo) DetectSailfishPhone() int {
+	return boolToInt(base.Device().SailfishPhone())
+}
+
+//**************************
+// Detects a mobile device running the Ubuntu Mobile OS.
+//
+// Deprecated: Use Device().Ubuntu() instead.
+func (base *UAgentInfo) DetectUbuntu() int {
+	return boolToInt(base.Device().Ubuntu())
+}
+
+//**************************
+// Detects a phone running the Ubuntu Mobile OS.
+//
+// Deprecated: Use Device().UbuntuPhone() instead.
+func (base *UAgentInfo) DetectUbuntuPhone() int {
+	return boolToInt(base.Device().UbuntuPhone())
+}
+
+//**************************
+// Detects a tablet running the Ubuntu Mobile OS.
+//
+// Deprecated: Use Device().UbuntuTablet() instead.
+func (base *UAgentInfo) DetectUbuntuTablet() int {
+	return boolToInt(base.Device().UbuntuTablet())
+}
+
+//**************************
+// Detects Huawei HarmonyOS. Older versions also pass DetectAndroid().
+//
+// Deprecated: Use Device().HarmonyOS() instead.
+func (base *UAgentInfo) DetectHarmonyOS() int {
+	return boolToInt(base.Device().HarmonyOS())
+}
+
+//**************************
+// Detects a phone running HarmonyOS.
+//
+// Deprecated: Use Device().HarmonyOSPhone() instead.
+func (base *UAgentInfo) DetectHarmonyOSPhone() int {
+	return boolToInt(base.Device().HarmonyOSPhone())
+}
+
+//**************************
+// Detects a tablet running HarmonyOS.
+//
+// Deprecated: Use Device().HarmonyOSTablet() instead.
+func (base *UAgentInfo) DetectHarmonyOSTablet() int {
+	return boolToInt(base.Device().HarmonyOSTablet())
+}
+
+//**************************
+// Detects a KaiOS feature phone. Its browser is modern, but the screen
+//   is small and there is no touch screen, so it is in the Rich CSS tier.
+//
+// Deprecated: Use Device().KaiOS() instead.
+func (base *UAgentInfo) DetectKaiOS() int {
+	return boolToInt(base.Device().KaiOS())
+}
+
+//**************************
+// Detects a Chromebook.
+//
+// Deprecated: Use Device().ChromeOS() instead.
+func (base *UAgentInfo) DetectChromeOS() int {
+	return boolToInt(base.Device().ChromeOS())
+}
+
+//**************************
+// Detects a foldable phone, such as the Galaxy Z Fold or Pixel Fold.
+//   The tier follows the UA: "Mobile" puts it in the iPhone tier.
+//
+// Deprecated: Use Device().Foldable() instead.
+func (base *UAgentInfo) DetectFoldable() int {
+	return boolToInt(base.Device().Foldable())
+}
+
+//**************************
+// Detects the Danger Hiptop device.
+//
+// Deprecated: Use Device().DangerHiptop() instead.
+func (base *UAgentInfo) DetectDangerHiptop() int {
+	return boolToInt(base.Device().DangerHiptop())
+}
+
+//**************************
+// Detects if the current browser is a Sony Mylo device.
+//
+// Deprecated: Use Device().SonyMylo() instead.
+func (base *UAgentInfo) DetectSonyMylo() int {
+	return boolToInt(base.Device().SonyMylo())
+}
+
+//**************************
+// Detects if the current device is on one of the Maemo-based Nokia Internet Tablets.
+//
+// Deprecated: Use Device().MaemoTablet() instead.
+func (base *UAgentInfo) DetectMaemoTablet() int {
+	return boolToInt(base.Device().MaemoTablet())
+}
+
+//**************************
+// Detects if the current device is an Archos media player/Internet tablet.
+//
+// Deprecated: Use Device().Archos() instead.
+func (base *UAgentInfo) DetectArchos() int {
+	return boolToInt(base.Device().Archos())
+}
+
+//**************************
+// Detects if the current device is an Internet-capable game console.
+// Includes many handheld consoles.
+//
+// Deprecated: Use Device().GameConsole() instead.
+func (base *UAgentInfo) DetectGameConsole() int {
+	return boolToInt(base.Device().GameConsole())
+}
+
+//**************************
+// Detects if the current device is a Sony Playstation.
+//
+// Deprecated: Use Device().SonyPlaystation() instead.
+func (base *UAgentInfo) DetectSonyPlaystation() int {
+	return boolToInt(base.Device().SonyPlaystation())
+}
+
+//**************************
+// Detects if the current device is a handheld gaming device with
+// a touchscreen and modern iPhone-class browser. Includes the Playstation Vita.
+//
+// Deprecated: Use Device().GamingHandheld() instead.
+func (base *UAgentInfo) DetectGamingHandheld() int {
+	return boolToInt(base.Device().GamingHandheld())
+}
+
+//**************************
+// Detects if the current device is a Nintendo game device.
+//
+// Deprecated: Use Device().Nintendo() instead.
+func (base *UAgentInfo) DetectNintendo() int {
+	return boolToInt(base.Device().Nintendo())
+}
+
+//**************************
+// Detects if the current device is a Microsoft Xbox.
+//
+// Deprecated: Use Device().Xbox() instead.
+func (base *UAgentInfo) DetectXbox() int {
+	return boolToInt(base.Device().Xbox())
+}
+
+//**************************
+// Detects whether the device is a Brew-powered device.
+//
+// Deprecated: Use Device().BrewDevice() instead.
+func (base *UAgentInfo) DetectBrewDevice() int {
+	return boolToInt(base.Device().BrewDevice())
+}
+
+//**************************
+// Detects whether the device supports WAP or WML.
+//
+// Deprecated: Use Device().WapWml() instead.
+func (base *UAgentInfo) DetectWapWml() int {
+	return boolToInt(base.Device().WapWml())
+}
+
+//**************************
+// Detects if the current device supports MIDP, a mobile Java technology.
+//
+// Deprecated: Use Device().MidpCapable() instead.
+func (base *UAgentInfo) DetectMidpCapable() int {
+	return boolToInt(base.Device().MidpCapable())
+}
+
+//**************************
+// Check to see whether the device is *any* 'smartphone'.
+//   Note: It's better to use DetectTierIphone() for modern touchscreen devices.
+//
+// Deprecated: Use Device().Smartphone() instead.
+func (base *UAgentInfo) DetectSmartphone() int {
+	return boolToInt(base.Device().Smartphone())
+}
+
+//**************************
+// The quick way to detect for a mobile device.
+//   Will probably detect most recent/current mid-tier Feature Phones
+//   as well as smartphone-class devices. Excludes Apple iPads and other modern tablets.
+//
+// Deprecated: Use Device().MobileQuick() instead.
+func (base *UAgentInfo) DetectMobileQuick() int {
+	return boolToInt(base.Device().MobileQuick())
+}
+
+//**************************
+// The longer and more thorough way to detect for a mobile device.
+//   Will probably detect most feature phones,
+//   smartphone-class devices, Internet Tablets,
+//   Internet-enabled game consoles, etc.
+//   This ought to catch a lot of the more obscure and older devices, also --
+//   but no promises on thoroughness!
+//
+// Deprecated: Use Device().MobileLong() instead.
+func (base *UAgentInfo) DetectMobileLong() int {
+	return boolToInt(base.Device().MobileLong())
+}
+
+//**************************
+// The quick way to detect for a tier of devices.
+//   This method detects for the new generation of
+//   HTML 5 capable, larger screen tablets.
+//   Includes iPad, Android (e.g., Xoom), BB Playbook, WebOS, etc.
+//
+// Deprecated: Use Device().TierTablet() instead.
+func (base *UAgentInfo) DetectTierTablet() int {
+	return boolToInt(base.Device().TierTablet())
+}
+
+//**************************
+// The quick way to detect for a tier of devices.
+//   This method detects for TVs, streaming sticks and game consoles,
+//   which need a "10-foot" UI: large type, and navigation by remote or gamepad.
+//   Includes Android TV, Fire TV, Roku, Apple TV, Tizen and WebOS TVs, Xbox, etc.
+//
+// Deprecated: Use Device().TierTenFoot() instead.
+func (base *UAgentInfo) DetectTierTenFoot() int {
+	return boolToInt(base.Device().TierTenFoot())
+}
+
+//**************************
+// The quick way to detect for a tier of devices.
+//   This method detects for devices which can
+//   display iPhone-optimized web content.
+//   Includes iPhone, iPod Touch, Android, Windows Phone, BB10, Playstation Vita, etc.
+//
+// Deprecated: Use Device().TierIphone() instead.
+func (base *UAgentInfo) DetectTierIphone() int {
+	return boolToInt(base.Device().TierIphone())
+}
+
+//**************************
+// The quick way to detect for a tier of devices.
+//   This method detects for devices which are likely to be capable
+//   of viewing CSS content optimized for the iPhone,
+//   but may not necessarily support JavaScript.
+//   Excludes all iPhone Tier devices.
+//
+// Deprecated: Use Device().TierRichCss() instead.
+func (base *UAgentInfo) DetectTierRichCss() int {
+	return boolToInt(base.Device().TierRichCss())
+}
+
+//**************************
+// The quick way to detect for a tier of devices.
+//   This method detects for all other types of phones,
+//   but excludes the iPhone and RichCSS Tier devices.
+//
+// Deprecated: Use Device().TierOtherPhones() instead.
+func (base *UAgentInfo) DetectTierOtherPhones() int {
+	return boolToInt(base.Device().TierOtherPhones())
+}
+
+//**************************
+// Detects search engine crawlers, link preview fetchers and uptime monitors.
+//
+// Deprecated: Use Device().Bot() instead.
+func (base *UAgentInfo) DetectBot() int {
+	return boolToInt(base.Device().Bot())
+}
+
+//**************************
+// Detects a crawler that presents itself as a mobile device,
+//   such as Googlebot smartphone or the mobile AdsBot.
+//
+// Deprecated: Use Device().MobileBot() instead.
+func (base *UAgentInfo) DetectMobileBot() int {
+	return boolToInt(base.Device().MobileBot())
+}
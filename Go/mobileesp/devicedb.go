@@ -0,0 +1,402 @@
+package mobileesp
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DeviceProperties describes the hardware of one device model.
+// Zero values, and nil Touch and Keyboard, mean the property is unknown.
+type DeviceProperties struct {
+	Vendor Vendor `json:"vendor,omitempty" yaml:"vendor,omitempty"`
+	Model  string `json:"model" yaml:"model"` //As returned by Model(). A trailing * matches any model starting with the rest.
+	Name   string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	ScreenWidth  int `json:"screenWidth,omitempty" yaml:"screenWidth,omitempty"`   //In pixels, as the device is usually held.
+	ScreenHeight int `json:"screenHeight,omitempty" yaml:"screenHeight,omitempty"` //In pixels, as the device is usually held.
+	PixelDensity int `json:"pixelDensity,omitempty" yaml:"pixelDensity,omitempty"` //In pixels per inch.
+	Year         int `json:"year,omitempty" yaml:"year,omitempty"`                 //The release year.
+
+	Touch      *bool      `json:"touch,omitempty" yaml:"touch,omitempty"`
+	Keyboard   *bool      `json:"keyboard,omitempty" yaml:"keyboard,omitempty"` //A hardware keyboard or keypad.
+	FormFactor FormFactor `json:"formFactor,omitempty" yaml:"formFactor,omitempty"`
+}
+
+// Returns a copy that shares no pointers with device, so the database
+// can't be changed through a returned Touch or Keyboard.
+func (device DeviceProperties) copy() DeviceProperties {
+	if device.Touch != nil {
+		touch := *device.Touch
+		device.Touch = &touch
+	}
+	if device.Keyboard != nil {
+		keyboard := *device.Keyboard
+		device.Keyboard = &keyboard
+	}
+	return device
+}
+
+// DeviceError reports which device of a device file is invalid.
+// Field uses the names of the device file format, such as "screenWidth".
+type DeviceError struct {
+	Model string
+	Field string
+	Err   string
+}
+
+func (e *DeviceError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("mobileesp: device %q: %s", e.Model, e.Err)
+	}
+	return fmt.Sprintf("mobileesp: device %q: %s: %s", e.Model, e.Field, e.Err)
+}
+
+// Formats accepted by ParseDevices.
+const (
+	DevicesJSON = "json"
+	DevicesYAML = "yaml"
+)
+
+// The version of the device file format.
+const devicesFileVersion = 1
+
+//go:embed data/devices.json
+var defaultDevicesFile []byte
+
+type deviceDatabase struct {
+	source   []DeviceProperties
+	exact    map[string]int //Lower case model to index of source.
+	prefixes []int          //Indexes of the models ending in *, longest first.
+}
+
+var (
+	devicesMutex  sync.RWMutex
+	activeDevices = mustCompileDevices(defaultDevices())
+)
+
+func defaultDevices() []DeviceProperties {
+	devices, err := ParseDevices(defaultDevicesFile, DevicesJSON)
+	if err != nil {
+		panic(err)
+	}
+	return devices
+}
+
+func mustCompileDevices(devices []DeviceProperties) *deviceDatabase {
+	compiled, err := compileDevices(devices)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+//**************************
+// Returns a copy of the built-in device database, embedded from data/devices.json.
+func DefaultDevices() []DeviceProperties {
+	return defaultDevices()
+}
+
+//**************************
+// Adds devices to the active database. A device with the model of an
+//   existing device replaces it; models match case-insensitively. Listing
+//   the same model twice in one call is an error. Meant to be called at
+//   startup, like RegisterRules.
+func RegisterDevices(devices ...DeviceProperties) error {
+	devicesMutex.Lock()
+	defer devicesMutex.Unlock()
+
+	merged := append([]DeviceProperties(nil), activeDevices.source...)
+	positions := map[string]int{}
+	for i, device := range merged {
+		positions[strings.ToLower(strings.TrimSpace(device.Model))] = i
+	}
+	registered := map[string]bool{}
+	for _, device := range devices {
+		key := strings.ToLower(strings.TrimSpace(device.Model))
+		if key != "" && registered[key] {
+			return &DeviceError{Model: device.Model, Field: "model", Err: "is defined twice"}
+		}
+		registered[key] = true
+
+		if i, ok := positions[key]; ok && key != "" {
+			merged[i] = device
+			continue
+		}
+		positions[key] = len(merged)
+		merged = append(merged, device)
+	}
+
+	compiled, err := compileDevices(merged)
+	if err != nil {
+		return err
+	}
+	activeDevices = compiled
+	return nil
+}
+
+//**************************
+// Replaces the active device database with the built-in one.
+func ResetDevices() {
+	devicesMutex.Lock()
+	defer devicesMutex.Unlock()
+	activeDevices = mustCompileDevices(defaultDevices())
+}
+
+func currentDevices() *deviceDatabase {
+	devicesMutex.RLock()
+	defer devicesMutex.RUnlock()
+	return activeDevices
+}
+
+func compileDevices(devices []DeviceProperties) (*deviceDatabase, error) {
+	compiled := &deviceDatabase{
+		source: make([]DeviceProperties, len(devices)),
+		exact:  make(map[string]int, len(devices)),
+	}
+	for i, device := range devices {
+		compiled.source[i] = device.copy()
+	}
+	prefixes := map[string]int{}
+	for i, device := range devices {
+		key := strings.ToLower(strings.TrimSpace(device.Model))
+		switch {
+		case key == "" || key == "*":
+			return nil, &DeviceError{Model: fmt.Sprintf("#%d", i), Field: "model", Err: "is empty"}
+		case strings.HasSuffix(key, "*"):
+			if _, ok := prefixes[key]; ok {
+				return nil, &DeviceError{Model: device.Model, Field: "model", Err: "is defined twice"}
+			}
+			prefixes[key] = i
+			compiled.prefixes = append(compiled.prefixes, i)
+		default:
+			if _, ok := compiled.exact[key]; ok {
+				return nil, &DeviceError{Model: device.Model, Field: "model", Err: "is defined twice"}
+			}
+			compiled.exact[key] = i
+		}
+	}
+	sort.SliceStable(compiled.prefixes, func(a, b int) bool {
+		return len(compiled.source[compiled.prefixes[a]].Model) > len(compiled.source[compiled.prefixes[b]].Model)
+	})
+	return compiled, nil
+}
+
+//**************************
+// Returns the properties of model in the active device database. Models
+//   match case-insensitively. When both vendors are known they must agree.
+func LookupDevice(vendor Vendor, model string) (DeviceProperties, bool) {
+	return currentDevices().lookup(vendor, model)
+}
+
+func (db *deviceDatabase) lookup(vendor Vendor, model string) (DeviceProperties, bool) {
+	key := strings.ToLower(strings.TrimSpace(model))
+	if key == "" {
+		return DeviceProperties{}, false
+	}
+	agrees := func(device DeviceProperties) bool {
+		return vendor == VendorUnknown || device.Vendor == VendorUnknown || device.Vendor == vendor
+	}
+
+	if i, ok := db.exact[key]; ok && agrees(db.source[i]) {
+		return db.source[i].copy(), true
+	}
+	for _, i := range db.prefixes {
+		device := db.source[i]
+		if strings.HasPrefix(key, strings.ToLower(strings.TrimSuffix(device.Model, "*"))) && agrees(device) {
+			return device.copy(), true
+		}
+	}
+	return DeviceProperties{}, false
+}
+
+//**************************
+// Looks up Vendor() and Model() in the active device database, for the
+//   screen size, input and release year of the device. Reports false
+//   when the UA names no model or the database doesn't list it.
+func (base *UAgentInfo) Properties() (DeviceProperties, bool) {
+	vendor, model := base.vendorModel()
+	return LookupDevice(vendor, model)
+}
+
+//**************************
+// Parses a device file, as JSON or YAML like ParseRules. The file is an
+//   object with "version": 1 and a "devices" list. Each device is an object
+//   with a "model" and any of "vendor", "name", "screenWidth",
+//   "screenHeight", "pixelDensity", "year", "touch", "keyboard" and
+//   "formFactor", matching the fields of DeviceProperties.
+//   Errors name the device and field that is wrong.
+func ParseDevices(data []byte, format string) ([]DeviceProperties, error) {
+	var document interface{}
+	var err error
+	switch strings.ToLower(format) {
+	case DevicesJSON:
+		err = json.Unmarshal(data, &document)
+	case DevicesYAML, "yml":
+		err = yaml.Unmarshal(data, &document)
+	default:
+		return nil, fmt.Errorf("mobileesp: unknown device file format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mobileesp: device file: %w", err)
+	}
+	return devicesFromDocument(document)
+}
+
+//**************************
+// Parses the device file at path, as JSON or YAML by its extension,
+//   and registers its devices with RegisterDevices.
+func LoadDevicesFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	devices, err := ParseDevices(data, format)
+	if err == nil {
+		err = RegisterDevices(devices...)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func devicesFromDocument(document interface{}) ([]DeviceProperties, error) {
+	top, ok := document.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("mobileesp: device file: must be an object with \"version\" and \"devices\"")
+	}
+	for _, key := range sortedKeys(top) {
+		if key != "version" && key != "devices" {
+			return nil, fmt.Errorf("mobileesp: device file: unknown field %q", key)
+		}
+	}
+	if version, ok := documentInt(top["version"]); !ok || version != devicesFileVersion {
+		return nil, fmt.Errorf("mobileesp: device file: \"version\" must be %d", devicesFileVersion)
+	}
+
+	items, ok := top["devices"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("mobileesp: device file: \"devices\" must be a list")
+	}
+
+	devices := make([]DeviceProperties, 0, len(items))
+	for i, item := range items {
+		device, err := deviceFromDocument(i, item)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+func deviceFromDocument(position int, item interface{}) (DeviceProperties, error) {
+	var device DeviceProperties
+	label := fmt.Sprintf("#%d", position)
+
+	fields, ok := item.(map[string]interface{})
+	if !ok {
+		return device, &DeviceError{Model: label, Err: "must be an object"}
+	}
+	if model, ok := fields["model"].(string); ok && strings.TrimSpace(model) != "" {
+		device.Model = strings.TrimSpace(model)
+		label = device.Model
+	} else {
+		return device, &DeviceError{Model: label, Field: "model", Err: "must be a non-empty string"}
+	}
+
+	numbers := map[string]*int{
+		"screenWidth":  &device.ScreenWidth,
+		"screenHeight": &device.ScreenHeight,
+		"pixelDensity": &device.PixelDensity,
+		"year":         &device.Year,
+	}
+	flags := map[string]**bool{
+		"touch":    &device.Touch,
+		"keyboard": &device.Keyboard,
+	}
+	for _, key := range sortedKeys(fields) {
+		value := fields[key]
+		switch {
+		case key == "model":
+		case key == "vendor" || key == "name" || key == "formFactor":
+			text, ok := value.(string)
+			if !ok {
+				return device, &DeviceError{Model: label, Field: key, Err: "must be a string"}
+			}
+			switch key {
+			case "vendor":
+				device.Vendor = canonicalVendor(text)
+			case "name":
+				device.Name = text
+			case "formFactor":
+				if !knownFormFactors[FormFactor(text)] {
+					return device, &DeviceError{Model: label, Field: key, Err: fmt.Sprintf("unknown form factor %q", text)}
+				}
+				device.FormFactor = FormFactor(text)
+			}
+		case numbers[key] != nil:
+			number, ok := documentInt(value)
+			if !ok || number < 0 {
+				return device, &DeviceError{Model: label, Field: key, Err: "must be a non-negative integer"}
+			}
+			*numbers[key] = number
+		case flags[key] != nil:
+			flag, ok := value.(bool)
+			if !ok {
+				return device, &DeviceError{Model: label, Field: key, Err: "must be true or false"}
+			}
+			*flags[key] = &flag
+		default:
+			return device, &DeviceError{Model: label, Field: key, Err: "unknown field"}
+		}
+	}
+	return device, nil
+}
+
+var knownFormFactors = map[FormFactor]bool{
+	FormFactorDesktop:    true,
+	FormFactorPhone:      true,
+	FormFactorTablet:     true,
+	FormFactorTV:         true,
+	FormFactorConsole:    true,
+	FormFactorEReader:    true,
+	FormFactorWearable:   true,
+	FormFactorAutomotive: true,
+}
+
+//**************************
+// Returns the canonical vendor for a manufacturer name, such as
+//   VendorXiaomi for "Redmi". Unknown names are kept, in lower case.
+func canonicalVendor(name string) Vendor {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if vendor, ok := vendorAliases[name]; ok {
+		return vendor
+	}
+	return Vendor(name)
+}
+
+//JSON decodes numbers as float64 and YAML as int.
+func documentInt(value interface{}) (int, bool) {
+	switch number := value.(type) {
+	case int:
+		return number, true
+	case float64:
+		if number != math.Trunc(number) || math.Abs(number) > math.MaxInt32 {
+			return 0, false
+		}
+		return int(number), true
+	}
+	return 0, false
+}
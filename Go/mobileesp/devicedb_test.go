@@ -0,0 +1,113 @@
+package mobileesp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestProperties(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"Mozilla/5.0 (Linux; Android 13; SAMSUNG SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/21.0 Chrome/110.0.5481.154 Mobile Safari/537.36", "Galaxy S23 Ultra"},
+		{"Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Mobile Safari/537.36", "Pixel 7"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Instagram 289.0.0.25.109 (iPhone14,5; iOS 16_5; en_US; en; scale=3.00; 1170x2532; 489393226)", "iPhone 13"},
+		{"Mozilla/5.0 (Linux; U; Android 4.0.3; en-us; KFTT Build/IML74K) AppleWebKit/535.19 (KHTML, like Gecko) Silk/2.1 Mobile Safari/535.19 Silk-Accelerated=true", "Kindle Fire HD 7"},
+		{"BlackBerry9700/5.0.0.351 Profile/MIDP-2.1 Configuration/CLDC-1.1 VendorID/123", "BlackBerry Bold 9700"},
+	}
+
+	for _, test := range tests {
+		properties, ok := NewFromStrings(test.userAgent, "").Properties()
+		if !ok || properties.Name != test.want {
+			t.Errorf("Properties() = %+v, %v, want %s\nUA: %s", properties, ok, test.want, test.userAgent)
+		}
+	}
+
+	properties, _ := NewFromStrings(tests[0].userAgent, "").Properties()
+	yes, no := true, false
+	want := DeviceProperties{Vendor: VendorSamsung, Model: "SM-S918*", Name: "Galaxy S23 Ultra", ScreenWidth: 1440, ScreenHeight: 3088, PixelDensity: 500, Year: 2023, Touch: &yes, Keyboard: &no, FormFactor: FormFactorPhone}
+	if !reflect.DeepEqual(properties, want) {
+		t.Errorf("Properties() = %+v, want %+v", properties, want)
+	}
+	*properties.Touch = false
+	if properties, _ := NewFromStrings(tests[0].userAgent, "").Properties(); !*properties.Touch {
+		t.Error("changing Properties().Touch changed the device database")
+	}
+
+	if _, ok := NewFromStrings("Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Mobile/15E148 Safari/604.1", "").Properties(); ok {
+		t.Error("Properties() found a plain \"iPhone\"")
+	}
+	if _, ok := LookupDevice(VendorApple, "Pixel 7"); ok {
+		t.Error("LookupDevice() ignored a vendor mismatch")
+	}
+}
+
+func TestLoadDevicesFile(t *testing.T) {
+	t.Cleanup(ResetDevices)
+	if err := LoadDevicesFile("testdata/devices.yaml"); err != nil {
+		t.Fatalf("LoadDevicesFile() = %v", err)
+	}
+
+	const redmi = "Mozilla/5.0 (Linux; Android 13; 23049PCD8G) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Mobile Safari/537.36"
+	properties, ok := NewFromStrings(redmi, "").Properties()
+	if !ok || properties.Vendor != VendorXiaomi || properties.PixelDensity != 395 {
+		t.Errorf("Properties() = %+v, %v, want the Redmi Note 12 Pro 5G from the file", properties, ok)
+	}
+	if properties, _ := LookupDevice(VendorSamsung, "SM-S918U"); properties.Name != "Galaxy S23 Ultra (test)" {
+		t.Errorf("LookupDevice() = %+v, want the file to replace the built-in device", properties)
+	} else if properties.Touch != nil || properties.Keyboard != nil {
+		t.Errorf("LookupDevice() = %+v, want touch and keyboard unknown when the file leaves them out", properties)
+	}
+
+	ResetDevices()
+	if _, ok := NewFromStrings(redmi, "").Properties(); ok {
+		t.Error("Properties() still finds the file's device after ResetDevices()")
+	}
+}
+
+func TestParseDevicesErrors(t *testing.T) {
+	t.Cleanup(ResetDevices)
+
+	tests := []struct {
+		format string
+		data   string
+		want   DeviceError
+	}{
+		{DevicesYAML, "version: 1\ndevices:\n  - name: Foo\n", DeviceError{Model: "#0", Field: "model"}},
+		{DevicesYAML, "version: 1\ndevices:\n  - model: F1\n    screenwidth: 10\n", DeviceError{Model: "F1", Field: "screenwidth"}},
+		{DevicesYAML, "version: 1\ndevices:\n  - model: F1\n    year: -1\n", DeviceError{Model: "F1", Field: "year"}},
+		{DevicesJSON, `{"version": 1, "devices": [{"model": "F1", "screenWidth": 1.5}]}`, DeviceError{Model: "F1", Field: "screenWidth"}},
+		{DevicesJSON, `{"version": 1, "devices": [{"model": "F1", "touch": "yes"}]}`, DeviceError{Model: "F1", Field: "touch"}},
+		{DevicesJSON, `{"version": 1, "devices": [{"model": "F1", "formFactor": "laptop"}]}`, DeviceError{Model: "F1", Field: "formFactor"}},
+		{DevicesJSON, `{"version": 1, "devices": ["F1"]}`, DeviceError{Model: "#0"}},
+	}
+
+	for _, test := range tests {
+		_, err := ParseDevices([]byte(test.data), test.format)
+		var deviceErr *DeviceError
+		if !errors.As(err, &deviceErr) {
+			t.Errorf("ParseDevices(%q) = %v, want a *DeviceError", test.data, err)
+			continue
+		}
+		if deviceErr.Model != test.want.Model || deviceErr.Field != test.want.Field {
+			t.Errorf("ParseDevices(%q) = %v, want device %q field %q", test.data, err, test.want.Model, test.want.Field)
+		}
+	}
+
+	for _, data := range []string{"devices: []\n", "version: 2\ndevices: []\n", "version: 1\ndevices: foo\n", "version: 1\ndevice: []\n"} {
+		if _, err := ParseDevices([]byte(data), DevicesYAML); err == nil {
+			t.Errorf("ParseDevices(%q) = nil, want an error", data)
+		}
+	}
+
+	err := RegisterDevices(DeviceProperties{Model: "F1"}, DeviceProperties{Model: "f1"})
+	var deviceErr *DeviceError
+	if !errors.As(err, &deviceErr) || deviceErr.Model != "f1" || deviceErr.Field != "model" {
+		t.Errorf("RegisterDevices() = %v, want F1 defined twice", err)
+	}
+	if _, ok := LookupDevice(VendorUnknown, "F1"); ok {
+		t.Error("failed RegisterDevices() changed the device database")
+	}
+}
@@ -0,0 +1,168 @@
+package mobileesp
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Explanation tells why a rule returned its result.
+type Explanation struct {
+	Rule   string
+	Result bool
+	Hinted bool //The client hints took the place of the UA token tests.
+
+	UserAgent []string //Tokens found in the User Agent that the rule looks for.
+	Accept    []string //Tokens found in the HTTP Accept value that the rule looks for.
+	Excluded  []string //Exclusions that fired: rule names and UA tokens.
+	Missing   []string //Required rules and UA tokens that were not found.
+
+	Steps []Explanation //The sub-detections behind the result.
+}
+
+// The top-level results explained by UAgentInfo.Explain(). They are
+// also the methods that ExcludingBots() turns off for bots.
+var explainedRules = []string{"mobilequick", "mobilelong", "tiertablet", "tiertenfoot", "tieriphone", "tierrichcss", "tierotherphones"}
+
+//**************************
+// Explains the tier and mobile results, such as DetectTierTablet() and
+//   DetectMobileQuick(): the sub-detections evaluated, the tokens matched
+//   and the exclusions that fired.
+func (base *UAgentInfo) Explain() []Explanation {
+	device := base.Device()
+	explanations := make([]Explanation, 0, len(explainedRules))
+	for _, name := range explainedRules {
+		explanation, _ := device.Explain(name)
+		explanations = append(explanations, explanation)
+	}
+	return explanations
+}
+
+//**************************
+// Explains the result of the rule called name, such as "tieriphone".
+//   ok is false for an unknown name.
+func (device Device) Explain(name string) (explanation Explanation, ok bool) {
+	name = ruleName(name)
+	i, ok := device.info.rules.index[name]
+	if !ok {
+		return Explanation{Rule: name}, false
+	}
+
+	//The tier and mobile methods of ExcludingBots() report false for bots.
+	if device.excludedBot() {
+		for _, explained := range explainedRules {
+			if explained == name {
+				bot, _ := device.Explain("bot")
+				return Explanation{Rule: name, Excluded: []string{bot.Rule}, Steps: []Explanation{bot}}, true
+			}
+		}
+	}
+	return device.explain(i), true
+}
+
+//**************************
+// Walks rule i in the same order as match(), recording what it finds.
+func (device Device) explain(i int) Explanation {
+	rule := &device.info.rules.list[i]
+	explanation := Explanation{Rule: rule.name, Result: device.evaluate(i)}
+	_, explanation.Hinted = device.hinted(rule.name)
+
+	userAgent, accept := device.tokens()
+	userAgentTokens := device.info.rules.userAgent.tokens
+	acceptTokens := device.info.rules.accept.tokens
+
+	for _, j := range rule.excludeRules {
+		if device.evaluate(j) {
+			step := device.explain(j)
+			explanation.Excluded = append(explanation.Excluded, step.Rule)
+			explanation.Steps = append(explanation.Steps, step)
+			return explanation
+		}
+	}
+	for _, token := range rule.exclude {
+		if userAgent.has(token) && !explanation.Hinted {
+			explanation.Excluded = append(explanation.Excluded, userAgentTokens[token])
+			return explanation
+		}
+	}
+	for _, j := range rule.requires {
+		step := device.explain(j)
+		explanation.Steps = append(explanation.Steps, step)
+		if !step.Result {
+			explanation.Missing = append(explanation.Missing, step.Rule)
+			return explanation
+		}
+	}
+	if explanation.Hinted {
+		return explanation
+	}
+	for _, token := range rule.userAgentAll {
+		if !userAgent.has(token) {
+			explanation.Missing = append(explanation.Missing, userAgentTokens[token])
+			return explanation
+		}
+		explanation.UserAgent = append(explanation.UserAgent, userAgentTokens[token])
+	}
+
+	for _, token := range rule.userAgent {
+		if userAgent.has(token) {
+			explanation.UserAgent = append(explanation.UserAgent, userAgentTokens[token])
+		}
+	}
+	for _, token := range rule.accept {
+		if accept.has(token) {
+			explanation.Accept = append(explanation.Accept, acceptTokens[token])
+		}
+	}
+	for _, j := range rule.rules {
+		if device.evaluate(j) {
+			explanation.Steps = append(explanation.Steps, device.explain(j))
+		}
+	}
+	return explanation
+}
+
+//**************************
+// Returns the explanation as an indented tree, one rule per line.
+func (explanation Explanation) String() string {
+	var text strings.Builder
+	explanation.write(&text, 0)
+	return text.String()
+}
+
+func (explanation Explanation) write(text *strings.Builder, depth int) {
+	text.WriteString(strings.Repeat("  ", depth))
+	text.WriteString(explanation.Rule)
+	if explanation.Result {
+		text.WriteString(" = true")
+	} else {
+		text.WriteString(" = false")
+	}
+	if explanation.Hinted {
+		text.WriteString(" (client hints)")
+	}
+	for _, part := range []struct {
+		label  string
+		values []string
+	}{
+		{"ua", explanation.UserAgent},
+		{"accept", explanation.Accept},
+		{"excluded by", explanation.Excluded},
+		{"missing", explanation.Missing},
+	} {
+		if len(part.values) > 0 {
+			text.WriteString("; " + part.label + " " + strings.Join(quoteAll(part.values), ", "))
+		}
+	}
+	text.WriteString("\n")
+	for _, step := range explanation.Steps {
+		step.write(text, depth+1)
+	}
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return quoted
+}
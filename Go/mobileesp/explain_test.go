@@ -0,0 +1,62 @@
+package mobileesp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	const kindleFire = "Mozilla/5.0 (Linux; U; Android 4.0.3; en-us; KFTT Build/IML74K) AppleWebKit/535.19 (KHTML, like Gecko) Silk/2.1 Mobile Safari/535.19 Silk-Accelerated=true"
+	detect := NewFromStrings(kindleFire, "")
+
+	explanations := detect.Explain()
+	if len(explanations) != len(explainedRules) {
+		t.Fatalf("Explain() returned %d results, want %d", len(explanations), len(explainedRules))
+	}
+	for _, explanation := range explanations {
+		if want := detect.Device().Is(explanation.Rule); explanation.Result != want {
+			t.Errorf("Explain() says %s = %v, want %v", explanation.Rule, explanation.Result, want)
+		}
+	}
+
+	quick, _ := detect.Device().Explain("MobileQuick")
+	if quick.Result || !reflect.DeepEqual(quick.Excluded, []string{"tiertablet"}) {
+		t.Errorf("mobilequick = %v excluded by %q, want false excluded by tiertablet", quick.Result, quick.Excluded)
+	}
+	tablet := quick.Steps[0].Steps[0]
+	if tablet.Rule != "firetablet" || !reflect.DeepEqual(tablet.UserAgent, []string{"; kf"}) {
+		t.Errorf("tiertablet step = %+v, want firetablet matching \"; kf\"", tablet)
+	}
+	if text := quick.String(); !strings.Contains(text, `firetablet = true; ua "; kf"`) {
+		t.Errorf("String() = %q, want the matched token", text)
+	}
+
+	if _, ok := detect.Device().Explain("nosuchrule"); ok {
+		t.Error("Explain() found an unknown rule")
+	}
+}
+
+func TestExplainAcceptAndMissing(t *testing.T) {
+	device := NewFromStrings("BlackBerry8520/5.0.0.681 Profile/MIDP-2.1 Configuration/CLDC-1.1 VendorID/114", "application/vnd.rim.html").Device()
+	blackberry, _ := device.Explain("blackberry")
+	if !blackberry.Result || !reflect.DeepEqual(blackberry.Accept, []string{vndRIM}) {
+		t.Errorf("blackberry = %+v, want the %q Accept token", blackberry, vndRIM)
+	}
+
+	tablet, _ := NewFromStrings("Mozilla/5.0 (Windows NT 10.0; Win64; x64)", "").Device().Explain("androidtablet")
+	if tablet.Result || !reflect.DeepEqual(tablet.Missing, []string{"android"}) {
+		t.Errorf("androidtablet = %+v, want false missing android", tablet)
+	}
+}
+
+func TestExplainExcludedBot(t *testing.T) {
+	device := NewFromStrings("Mozilla/5.0 (iPhone; CPU iPhone OS 6_0 like Mac OS X) AppleWebKit/536.26 (KHTML, like Gecko) Version/6.0 Mobile/10A5376e Safari/8536.25 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", "").Device()
+	explanation, _ := device.ExcludingBots().Explain("tieriphone")
+	if explanation.Result || !reflect.DeepEqual(explanation.Excluded, []string{"bot"}) {
+		t.Errorf("tieriphone = %+v, want false excluded by bot", explanation)
+	}
+	if explanation, _ := device.Explain("tieriphone"); !explanation.Result {
+		t.Error("tieriphone = false without ExcludingBots()")
+	}
+}
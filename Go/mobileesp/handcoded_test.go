@@ -0,0 +1,1137 @@
+package mobileesp
+
+import "strings"
+
+// handCodedDevice is the detection as it was before the rule engine: one
+// hand-written method per detection, each testing its tokens with
+// strings.Index and memoizing its result. It is kept, unchanged apart from
+// its type names, as the baseline of BenchmarkDetection. Client hints are
+// left out; the benchmark sends none.
+type handCodedDevice struct {
+	info        *handCodedInfo
+	excludeBots bool
+}
+
+type handCodedInfo struct {
+	userAgentHeader  string
+	httpAcceptHeader string
+	results          [scanCount]scanState
+}
+
+// Creates the detection and runs the detections of initDeviceScan, as
+// NewFromStrings did.
+func newHandCoded(userAgent string, httpAccept string) handCodedDevice {
+	device := handCodedDevice{info: &handCodedInfo{
+		userAgentHeader:  strings.ToLower(userAgent),
+		httpAcceptHeader: strings.ToLower(httpAccept),
+	}}
+	device.Webkit()
+	device.Iphone()
+	device.Android()
+	device.AndroidPhone()
+	device.MobileQuick()
+	device.TierIphone()
+	device.TierTablet()
+	device.TierRichCss()
+	device.TierOtherPhones()
+	return device
+}
+
+func (device handCodedDevice) memo(id detection, scan func() bool) bool {
+	results := &device.info.results
+	switch results[id] {
+	case scanYes:
+		return true
+	case scanNo:
+		return false
+	case scanRunning:
+		panic("mobileesp: detection depends on itself")
+	}
+
+	results[id] = scanRunning
+	if scan() {
+		results[id] = scanYes
+		return true
+	}
+	results[id] = scanNo
+	return false
+}
+
+// Start device detection
+//*****************************
+
+//**************************
+// Detects if the current device is an iPhone.
+func (device handCodedDevice) Iphone() bool {
+	return device.memo(scanIphone, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceIphone) > -1 {
+			//The iPad and iPod Touch say they're an iPhone. So let's disambiguate.
+			if device.Ipad() || device.Ipod() {
+				return false
+			} else {
+				//Yay! It's an iPhone!
+				return true
+			}
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is an iPod Touch.
+func (device handCodedDevice) Ipod() bool {
+	return device.memo(scanIpod, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceIpod) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is an iPad tablet.
+func (device handCodedDevice) Ipad() bool {
+	return device.memo(scanIpad, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceIpad) > -1 && device.Webkit() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is an iPhone or iPod Touch.
+func (device handCodedDevice) IphoneOrIpod() bool {
+	return device.memo(scanIphoneOrIpod, func() bool {
+		//We repeat the searches here because some iPods may report themselves as an iPhone, which would be okay.
+		if device.Iphone() || device.Ipod() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects *any* iOS device: iPhone, iPod Touch, iPad.
+func (device handCodedDevice) Ios() bool {
+	return device.memo(scanIos, func() bool {
+		if device.IphoneOrIpod() || device.Ipad() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects *any* Android OS-based device: phone, tablet, and multi-media player.
+// Also detects Google TV.
+func (device handCodedDevice) Android() bool {
+	return device.memo(scanAndroid, func() bool {
+		if (strings.Index(device.info.userAgentHeader, deviceAndroid) > -1) || device.GoogleTV() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is a (small-ish) Android OS-based device
+// used for calling and/or multi-media (like a Samsung Galaxy Player).
+// Google says these devices will have 'Android' AND 'mobile' in user agent.
+// Ignores tablets (Honeycomb and later).
+func (device handCodedDevice) AndroidPhone() bool {
+	return device.memo(scanAndroidPhone, func() bool {
+		//First, let's make sure we're on an Android device.
+		if !device.Android() {
+			return false
+		}
+
+		//If it's Android and has 'mobile' in it, Google says it's a phone.
+		if strings.Index(device.info.userAgentHeader, mobile) > -1 {
+			return true
+		}
+		//Special check for Android devices with Opera Mobile/Mini. They should report here.
+		if device.OperaMobile() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is a (self-reported) Android tablet.
+// Google says these devices will have 'Android' and NOT 'mobile' in their user agent.
+func (device handCodedDevice) AndroidTablet() bool {
+	return device.memo(scanAndroidTablet, func() bool {
+		//First, let's make sure we're on an Android device.
+		if !device.Android() {
+			return false
+		}
+
+		//Special check for Android devices with Opera Mobile/Mini. They should NOT report here.
+		if device.OperaMobile() {
+			return false
+		}
+
+		//Otherwise, if it's Android and does NOT have 'mobile' in it, Google says it's a tablet.
+		if strings.Index(device.info.userAgentHeader, mobile) > -1 {
+			return false
+		} else {
+			return true
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is an Android OS-based device and
+//   the browser is based on WebKit.
+func (device handCodedDevice) AndroidWebKit() bool {
+	return device.memo(scanAndroidWebKit, func() bool {
+		if device.Android() && device.Webkit() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is a GoogleTV.
+func (device handCodedDevice) GoogleTV() bool {
+	return device.memo(scanGoogleTV, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceGoogleTV) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is based on WebKit.
+func (device handCodedDevice) Webkit() bool {
+	return device.memo(scanWebkit, func() bool {
+		if strings.Index(device.info.userAgentHeader, engineWebKit) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is a
+// Windows Phone 7, 8, or 10 device.
+func (device handCodedDevice) WindowsPhone() bool {
+	return device.memo(scanWindowsPhone, func() bool {
+		if device.WindowsPhone7() || device.WindowsPhone8() || device.WindowsPhone10() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a Windows Phone 7 device (in mobile browsing mode).
+func (device handCodedDevice) WindowsPhone7() bool {
+	return device.memo(scanWindowsPhone7, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceWinPhone7) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a Windows Phone 8 device (in mobile browsing mode).
+func (device handCodedDevice) WindowsPhone8() bool {
+	return device.memo(scanWindowsPhone8, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceWinPhone8) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a Windows Phone 10 device (in mobile browsing mode).
+func (device handCodedDevice) WindowsPhone10() bool {
+	return device.memo(scanWindowsPhone10, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceWinPhone10) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is a Windows Mobile device.
+// Excludes Windows Phone 7 and later devices.
+// Focuses on Windows Mobile 6.xx and earlier.
+func (device handCodedDevice) WindowsMobile() bool {
+	return device.memo(scanWindowsMobile, func() bool {
+		if device.WindowsPhone() {
+			return false
+		}
+
+		//Most devices use 'Windows CE', but some report 'iemobile'
+		//  and some older ones report as 'PIE' for Pocket IE.
+		if strings.Index(device.info.userAgentHeader, deviceWinMob) > -1 || strings.Index(device.info.userAgentHeader, deviceIeMob) > -1 ||
+			strings.Index(device.info.userAgentHeader, enginePie) > -1 {
+			return true
+		} //Test for Windows Mobile PPC but not old Macintosh PowerPC.
+		if strings.Index(device.info.userAgentHeader, devicePpc) > -1 && !(strings.Index(device.info.userAgentHeader, deviceMacPpc) > 1) {
+			return true
+		} //Test for certain Windwos Mobile-based HTC devices.
+		if strings.Index(device.info.userAgentHeader, manuHtc) > -1 && strings.Index(device.info.userAgentHeader, deviceWindows) > -1 {
+			return true
+		}
+		if device.WapWml() && strings.Index(device.info.userAgentHeader, deviceWindows) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is any BlackBerry device.
+// Includes BB10 OS, but excludes the PlayBook.
+func (device handCodedDevice) BlackBerry() bool {
+	return device.memo(scanBlackBerry, func() bool {
+		if (strings.Index(device.info.userAgentHeader, deviceBB) > -1) || (strings.Index(device.info.httpAcceptHeader, vndRIM) > -1) {
+			return true
+		}
+		if device.BlackBerry10Phone() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is a BlackBerry 10 OS phone.
+// Excludes tablets.
+func (device handCodedDevice) BlackBerry10Phone() bool {
+	return device.memo(scanBlackBerry10Phone, func() bool {
+		if (strings.Index(device.info.userAgentHeader, deviceBB10) > -1) && (strings.Index(device.info.userAgentHeader, mobile) > -1) {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is on a BlackBerry tablet device.
+//    Examples: PlayBook
+func (device handCodedDevice) BlackBerryTablet() bool {
+	return device.memo(scanBlackBerryTablet, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceBBPlaybook) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is a BlackBerry phone device AND uses a
+//    WebKit-based browser. These are signatures for the new BlackBerry OS 6.
+//    Examples: Torch. Includes the Playbook.
+func (device handCodedDevice) BlackBerryWebKit() bool {
+	return device.memo(scanBlackBerryWebKit, func() bool {
+		if device.BlackBerry() && device.Webkit() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is a BlackBerry Touch phone device with
+//    a large screen, such as the Storm, Torch, and Bold Touch. Excludes the Playbook.
+func (device handCodedDevice) BlackBerryTouch() bool {
+	return device.memo(scanBlackBerryTouch, func() bool {
+		if (strings.Index(device.info.userAgentHeader, deviceBBStorm) > -1) || (strings.Index(device.info.userAgentHeader, deviceBBTorch) > -1) ||
+			(strings.Index(device.info.userAgentHeader, deviceBBBoldTouch) > -1) || (strings.Index(device.info.userAgentHeader, deviceBBCurveTouch) > -1) {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is a BlackBerry OS 5 device AND
+//    has a more capable recent browser. Excludes the Playbook.
+//    Examples, Storm, Bold, Tour, Curve2
+//    Excludes the new BlackBerry OS 6 and 7 browser!!
+func (device handCodedDevice) BlackBerryHigh() bool {
+	return device.memo(scanBlackBerryHigh, func() bool {
+		//Disambiguate for BlackBerry OS 6 or 7 (WebKit) browser
+		if device.BlackBerryWebKit() {
+			return false
+		}
+		if device.BlackBerry() {
+			if device.BlackBerryTouch() || strings.Index(device.info.userAgentHeader, deviceBBBold) > -1 ||
+				strings.Index(device.info.userAgentHeader, deviceBBTour) > -1 || strings.Index(device.info.userAgentHeader, deviceBBCurve) > -1 {
+				{
+					return true
+				}
+			} else {
+				return false
+			}
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is a BlackBerry device AND
+//    has an older, less capable browser.
+//    Examples: Pearl, 8800, Curve1.
+func (device handCodedDevice) BlackBerryLow() bool {
+	return device.memo(scanBlackBerryLow, func() bool {
+		if device.BlackBerry() {
+			//Assume that if it's not in the High tier, then it's Low.
+			if device.BlackBerryHigh() || device.BlackBerryWebKit() {
+				return false
+			} else {
+				return true
+			}
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is the Nokia S60 Open Source Browser.
+func (device handCodedDevice) S60OssBrowser() bool {
+	return device.memo(scanS60OssBrowser, func() bool {
+		//First, test for WebKit, then make sure it's either Symbian or S60.
+		if device.Webkit() {
+			if strings.Index(device.info.userAgentHeader, deviceSymbian) > -1 || strings.Index(device.info.userAgentHeader, deviceS60) > -1 {
+				{
+					return true
+				}
+			} else {
+				return false
+			}
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is any Symbian OS-based device,
+//   including older S60, Series 70, Series 80, Series 90, and UIQ,
+//   or other browsers running on these devices.
+func (device handCodedDevice) SymbianOS() bool {
+	return device.memo(scanSymbianOS, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceSymbian) > -1 || strings.Index(device.info.userAgentHeader, deviceS60) > -1 ||
+			strings.Index(device.info.userAgentHeader, deviceS70) > -1 || strings.Index(device.info.userAgentHeader, deviceS80) > -1 ||
+			strings.Index(device.info.userAgentHeader, deviceS90) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is on a PalmOS device.
+func (device handCodedDevice) PalmOS() bool {
+	return device.memo(scanPalmOS, func() bool {
+		//Most devices nowadays report as 'Palm', but some older ones reported as Blazer or Xiino.
+		if strings.Index(device.info.userAgentHeader, devicePalm) > -1 ||
+			strings.Index(device.info.userAgentHeader, engineBlazer) > -1 ||
+			strings.Index(device.info.userAgentHeader, engineXiino) > -1 {
+			//Make sure it's not WebOS first
+			if device.PalmWebOS() {
+				return false
+			} else {
+				return true
+			}
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is on a Palm device
+//   running the new WebOS.
+func (device handCodedDevice) PalmWebOS() bool {
+	return device.memo(scanPalmWebOS, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceWebOS) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is on an HP tablet running WebOS.
+func (device handCodedDevice) WebOSTablet() bool {
+	return device.memo(scanWebOSTablet, func() bool {
+		if (strings.Index(device.info.userAgentHeader, deviceWebOShp) > -1) && (strings.Index(device.info.userAgentHeader, deviceTablet) > -1) {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is on a WebOS smart TV.
+func (device handCodedDevice) WebOSTV() bool {
+	return device.memo(scanWebOSTV, func() bool {
+		if (strings.Index(device.info.userAgentHeader, deviceWebOStv) > -1) && (strings.Index(device.info.userAgentHeader, smartTV2) > -1) {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is Opera Mobile or Mini.
+func (device handCodedDevice) OperaMobile() bool {
+	return device.memo(scanOperaMobile, func() bool {
+		if (strings.Index(device.info.userAgentHeader, engineOpera) > -1) &&
+			((strings.Index(device.info.userAgentHeader, mini) > -1) ||
+				(strings.Index(device.info.userAgentHeader, mobi) > -1)) {
+			return true
+		}
+		return false
+	})
+}
+
+//**************************
+// Detects if the current device is an Amazon Kindle (eInk devices only).
+// Note: For the Kindle Fire, use the normal Android methods.
+func (device handCodedDevice) Kindle() bool {
+	return device.memo(scanKindle, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceKindle) > -1 &&
+			!device.Android() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current Amazon device has turned on the Silk accelerated browsing feature.
+// Note: Typically used by the the Kindle Fire.
+func (device handCodedDevice) AmazonSilk() bool {
+	return device.memo(scanAmazonSilk, func() bool {
+		if strings.Index(device.info.userAgentHeader, engineSilk) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if a Garmin Nuvifone device.
+func (device handCodedDevice) GarminNuvifone() bool {
+	return device.memo(scanGarminNuvifone, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceNuvifone) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a device running the Bada OS from Samsung.
+func (device handCodedDevice) Bada() bool {
+	return device.memo(scanBada, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceBada) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a device running the Tizen smartphone OS.
+func (device handCodedDevice) Tizen() bool {
+	return device.memo(scanTizen, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceTizen) > -1 && (strings.Index(device.info.userAgentHeader, mobile) > -1) {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is on a Tizen smart TV.
+func (device handCodedDevice) TizenTV() bool {
+	return device.memo(scanTizenTV, func() bool {
+		if (strings.Index(device.info.userAgentHeader, deviceTizen) > -1) && (strings.Index(device.info.userAgentHeader, smartTV1) > -1) {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a device running the Meego OS.
+func (device handCodedDevice) Meego() bool {
+	return device.memo(scanMeego, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceMeego) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a phone running the Meego OS.
+func (device handCodedDevice) MeegoPhone() bool {
+	return device.memo(scanMeegoPhone, func() bool {
+		if (strings.Index(device.info.userAgentHeader, deviceMeego) > -1) && (strings.Index(device.info.userAgentHeader, mobi) > -1) {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a mobile device (probably) running the Firefox OS.
+func (device handCodedDevice) FirefoxOS() bool {
+	return device.memo(scanFirefoxOS, func() bool {
+		if device.FirefoxOSPhone() || device.FirefoxOSTablet() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a phone (probably) running the Firefox OS.
+func (device handCodedDevice) FirefoxOSPhone() bool {
+	return device.memo(scanFirefoxOSPhone, func() bool {
+		//First, let's make sure we're NOT on another major mobile OS.
+		if device.Ios() || device.Android() || device.Sailfish() {
+			return false
+		}
+
+		if (strings.Index(device.info.userAgentHeader, engineFirefox) > -1) &&
+			(strings.Index(device.info.userAgentHeader, mobile) > -1) {
+			return true
+		}
+		return false
+	})
+}
+
+//**************************
+// Detects a tablet (probably) running the Firefox OS.
+func (device handCodedDevice) FirefoxOSTablet() bool {
+	return device.memo(scanFirefoxOSTablet, func() bool {
+		//First, let's make sure we're NOT on another major mobile OS.
+		if device.Ios() || device.Android() || device.Sailfish() {
+			return false
+		}
+
+		if (strings.Index(device.info.userAgentHeader, engineFirefox) > -1) &&
+			(strings.Index(device.info.userAgentHeader, deviceTablet) > -1) {
+			return true
+		}
+		return false
+	})
+}
+
+//**************************
+// Detects a device running the Sailfish OS.
+func (device handCodedDevice) Sailfish() bool {
+	return device.memo(scanSailfish, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceSailfish) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a phone running the Sailfish OS.
+func (device handCodedDevice) SailfishPhone() bool {
+	return device.memo(scanSailfishPhone, func() bool {
+		if device.Sailfish() &&
+			(strings.Index(device.info.userAgentHeader, mobile) > -1) {
+			return true
+		}
+		return false
+	})
+}
+
+//**************************
+// Detects a mobile device running the Ubuntu Mobile OS.
+func (device handCodedDevice) Ubuntu() bool {
+	return device.memo(scanUbuntu, func() bool {
+		if device.UbuntuPhone() || device.UbuntuTablet() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects a phone running the Ubuntu Mobile OS.
+func (device handCodedDevice) UbuntuPhone() bool {
+	return device.memo(scanUbuntuPhone, func() bool {
+		if (strings.Index(device.info.userAgentHeader, deviceUbuntu) > -1) && (strings.Index(device.info.userAgentHeader, mobile) > -1) {
+			return true
+		}
+		return false
+	})
+}
+
+//**************************
+// Detects a tablet running the Ubuntu Mobile OS.
+func (device handCodedDevice) UbuntuTablet() bool {
+	return device.memo(scanUbuntuTablet, func() bool {
+		if (strings.Index(device.info.userAgentHeader, deviceUbuntu) > -1) &&
+			(strings.Index(device.info.userAgentHeader, deviceTablet) > -1) {
+			return true
+		}
+		return false
+	})
+}
+
+//**************************
+// Detects the Danger Hiptop device.
+func (device handCodedDevice) DangerHiptop() bool {
+	return device.memo(scanDangerHiptop, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceDanger) > -1 ||
+			strings.Index(device.info.userAgentHeader, deviceHiptop) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current browser is a Sony Mylo device.
+func (device handCodedDevice) SonyMylo() bool {
+	return device.memo(scanSonyMylo, func() bool {
+		if (strings.Index(device.info.userAgentHeader, manuSony) > -1) &&
+			((strings.Index(device.info.userAgentHeader, qtembedded) > -1) ||
+				(strings.Index(device.info.userAgentHeader, mylocom2) > -1)) {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is on one of the Maemo-based Nokia Internet Tablets.
+func (device handCodedDevice) MaemoTablet() bool {
+	return device.memo(scanMaemoTablet, func() bool {
+		if strings.Index(device.info.userAgentHeader, maemo) > -1 {
+			return true
+		} //For Nokia N810, must be Linux + Tablet, or else it could be something else.
+		if (strings.Index(device.info.userAgentHeader, linux) > -1) && (strings.Index(device.info.userAgentHeader, deviceTablet) > -1) && !device.WebOSTablet() && !device.Android() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is an Archos media player/Internet tablet.
+func (device handCodedDevice) Archos() bool {
+	return device.memo(scanArchos, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceArchos) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is an Internet-capable game console.
+// Includes many handheld consoles.
+func (device handCodedDevice) GameConsole() bool {
+	return device.memo(scanGameConsole, func() bool {
+		if device.SonyPlaystation() {
+			return true
+		} else if device.Nintendo() {
+			return true
+		} else if device.Xbox() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is a Sony Playstation.
+func (device handCodedDevice) SonyPlaystation() bool {
+	return device.memo(scanSonyPlaystation, func() bool {
+		if strings.Index(device.info.userAgentHeader, devicePlaystation) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is a handheld gaming device with
+// a touchscreen and modern iPhone-class browser. Includes the Playstation Vita.
+func (device handCodedDevice) GamingHandheld() bool {
+	return device.memo(scanGamingHandheld, func() bool {
+		if (strings.Index(device.info.userAgentHeader, devicePlaystation) > -1) &&
+			(strings.Index(device.info.userAgentHeader, devicePlaystationVita) > -1) {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is a Nintendo game device.
+func (device handCodedDevice) Nintendo() bool {
+	return device.memo(scanNintendo, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceNintendo) > -1 ||
+			strings.Index(device.info.userAgentHeader, deviceWii) > -1 ||
+			strings.Index(device.info.userAgentHeader, deviceNintendoDs) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device is a Microsoft Xbox.
+func (device handCodedDevice) Xbox() bool {
+	return device.memo(scanXbox, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceXbox) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects whether the device is a Brew-powered device.
+func (device handCodedDevice) BrewDevice() bool {
+	return device.memo(scanBrewDevice, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceBrew) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects whether the device supports WAP or WML.
+func (device handCodedDevice) WapWml() bool {
+	return device.memo(scanWapWml, func() bool {
+		if strings.Index(device.info.httpAcceptHeader, vndwap) > -1 ||
+			strings.Index(device.info.httpAcceptHeader, wml) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects if the current device supports MIDP, a mobile Java technology.
+func (device handCodedDevice) MidpCapable() bool {
+	return device.memo(scanMidpCapable, func() bool {
+		if strings.Index(device.info.userAgentHeader, deviceMidp) > -1 ||
+			strings.Index(device.info.httpAcceptHeader, deviceMidp) > -1 {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Detects search engine crawlers, link preview fetchers and uptime monitors.
+func (device handCodedDevice) Bot() bool {
+	return device.memo(scanBot, func() bool {
+		for _, token := range botTokens {
+			if strings.Index(device.info.userAgentHeader, token) > -1 {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+//**************************
+// Detects a crawler that presents itself as a mobile device,
+//   such as Googlebot smartphone or the mobile AdsBot.
+func (device handCodedDevice) MobileBot() bool {
+	return device.memo(scanMobileBot, func() bool {
+		if device.Bot() && (handCodedDevice{info: device.info}).MobileQuick() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// Returns a view whose tier and mobile methods report false for bots,
+//   so crawlers don't count as phones or tablets.
+func (device handCodedDevice) ExcludingBots() handCodedDevice {
+	device.excludeBots = true
+	return device
+}
+
+func (device handCodedDevice) excludedBot() bool {
+	return device.excludeBots && device.Bot()
+}
+
+//*****************************
+// Device Classes
+//*****************************
+
+//**************************
+// Check to see whether the device is *any* 'smartphone'.
+//   Note: It's better to use DetectTierIphone() for modern touchscreen devices.
+func (device handCodedDevice) Smartphone() bool {
+	return device.memo(scanSmartphone, func() bool {
+		//Exclude duplicates from TierIphone
+		if device.TierIphone() || device.S60OssBrowser() || device.SymbianOS() ||
+			device.WindowsMobile() || device.BlackBerry() || device.MeegoPhone() ||
+			device.PalmWebOS() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// The quick way to detect for a mobile device.
+//   Will probably detect most recent/current mid-tier Feature Phones
+//   as well as smartphone-class devices. Excludes Apple iPads and other modern tablets.
+func (device handCodedDevice) MobileQuick() bool {
+	if device.excludedBot() {
+		return false
+	}
+	return device.memo(scanMobileQuick, func() bool {
+		//Let's exclude tablets
+		if device.TierTablet() {
+			return false
+		}
+
+		//Most mobile browsing is done on smartphones
+		if device.Smartphone() {
+			return true
+		}
+		//Catch-all for many mobile devices
+		if strings.Index(device.info.userAgentHeader, mobile) > -1 {
+			return true
+		}
+		if device.OperaMobile() {
+			return true
+		}
+		//We also look for Kindle devices
+		if device.Kindle() ||
+			device.AmazonSilk() {
+			return true
+		}
+		if device.WapWml() || device.MidpCapable() || device.BrewDevice() {
+			return true
+		}
+		if (strings.Index(device.info.userAgentHeader, engineNetfront) > -1) || (strings.Index(device.info.userAgentHeader, engineUpBrowser) > -1) {
+			return true
+		}
+		return false
+	})
+}
+
+//**************************
+// The longer and more thorough way to detect for a mobile device.
+//   Will probably detect most feature phones,
+//   smartphone-class devices, Internet Tablets,
+//   Internet-enabled game consoles, etc.
+//   This ought to catch a lot of the more obscure and older devices, also --
+//   but no promises on thoroughness!
+func (device handCodedDevice) MobileLong() bool {
+	if device.excludedBot() {
+		return false
+	}
+	return device.memo(scanMobileLong, func() bool {
+		if device.MobileQuick() {
+			return true
+		}
+		if device.GameConsole() {
+			return true
+		}
+		if device.DangerHiptop() || device.MaemoTablet() || device.SonyMylo() ||
+			device.Archos() {
+			return true
+		}
+		if (strings.Index(device.info.userAgentHeader, devicePda) > -1) && !(strings.Index(device.info.userAgentHeader, disUpdate) > -1) {
+			return true
+		}
+		//Detect older phones from certain manufacturers and operators.
+		if (strings.Index(device.info.userAgentHeader, uplink) > -1) || (strings.Index(device.info.userAgentHeader, engineOpenWeb) > -1) ||
+			(strings.Index(device.info.userAgentHeader, manuSamsung1) > -1) || (strings.Index(device.info.userAgentHeader, manuSonyEricsson) > -1) ||
+			(strings.Index(device.info.userAgentHeader, manuericsson) > -1) || (strings.Index(device.info.userAgentHeader, svcDocomo) > -1) ||
+			(strings.Index(device.info.userAgentHeader, svcKddi) > -1) || (strings.Index(device.info.userAgentHeader, svcVodafone) > -1) {
+			return true
+		}
+		return false
+	})
+}
+
+//*****************************
+// For Mobile Web Site Design
+//*****************************
+
+//**************************
+// The quick way to detect for a tier of devices.
+//   This method detects for the new generation of
+//   HTML 5 capable, larger screen tablets.
+//   Includes iPad, Android (e.g., Xoom), BB Playbook, WebOS, etc.
+func (device handCodedDevice) TierTablet() bool {
+	if device.excludedBot() {
+		return false
+	}
+	return device.memo(scanTierTablet, func() bool {
+		if device.Ipad() || device.AndroidTablet() || device.BlackBerryTablet() ||
+			device.FirefoxOSTablet() || device.UbuntuTablet() || device.WebOSTablet() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// The quick way to detect for a tier of devices.
+//   This method detects for devices which can
+//   display iPhone-optimized web content.
+//   Includes iPhone, iPod Touch, Android, Windows Phone, BB10, Playstation Vita, etc.
+func (device handCodedDevice) TierIphone() bool {
+	if device.excludedBot() {
+		return false
+	}
+	return device.memo(scanTierIphone, func() bool {
+		if device.IphoneOrIpod() || device.AndroidPhone() || device.WindowsPhone() ||
+			device.BlackBerry10Phone() || device.PalmWebOS() || device.Bada() ||
+			device.Tizen() || device.FirefoxOSPhone() || device.SailfishPhone() ||
+			device.UbuntuPhone() || device.GamingHandheld() {
+			return true
+		}
+		//Note: BB10 phone is in the previous paragraph
+		if device.BlackBerryWebKit() &&
+			device.BlackBerryTouch() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// The quick way to detect for a tier of devices.
+//   This method detects for devices which are likely to be capable
+//   of viewing CSS content optimized for the iPhone,
+//   but may not necessarily support JavaScript.
+//   Excludes all iPhone Tier devices.
+func (device handCodedDevice) TierRichCss() bool {
+	if device.excludedBot() {
+		return false
+	}
+	return device.memo(scanTierRichCss, func() bool {
+		if device.MobileQuick() {
+			//Exclude iPhone Tier and e-Ink Kindle devices
+			if device.TierIphone() || device.Kindle() {
+				return false
+			}
+
+			//The following devices are explicitly ok.
+			if device.Webkit() {
+				//Any WebKit
+				return true
+			}
+			if device.S60OssBrowser() {
+				return true
+			}
+			//Note: 'High' BlackBerry devices ONLY
+			if device.BlackBerryHigh() {
+				return true
+			}
+			//Older Windows 'Mobile' isn't good enough for iPhone Tier.
+			if device.WindowsMobile() {
+				return true
+			}
+			if strings.Index(device.info.userAgentHeader, engineTelecaQ) > -1 {
+				return true
+			} else {
+				//default
+				return false
+			}
+		} else {
+			return false
+		}
+	})
+}
+
+//**************************
+// The quick way to detect for a tier of devices.
+//   This method detects for all other types of phones,
+//   but excludes the iPhone and RichCSS Tier devices.
+func (device handCodedDevice) TierOtherPhones() bool {
+	if device.excludedBot() {
+		return false
+	}
+	return device.memo(scanTierOtherPhones, func() bool {
+		//Exclude devices in the other 2 categories
+		if device.MobileLong() && !device.TierIphone() && !device.TierRichCss() {
+			return true
+		} else {
+			return false
+		}
+	})
+}
@@ -76,23 +76,24 @@ package mobileesp
 //   The methods were written so you can be as granular as you want.
 //   For example, enquiring whether it's as specific as an iPod Touch or
 //   as general as a smartphone class device.
-//   The object's methods return 1 for true, or 0 for false.
+//   The Device() view returns a bool for each detection. The older
+//   Detect*() methods return 1 for true, or 0 for false.
 
 import (
 	"net/http"
 	"strings"
 )
 
-//standardized values for true and false.
-const true = 1
-const false = 0
-
 //Initialize some initial smartphone string variables.
 const engineWebKit = "webkit"
 const deviceIphone = "iphone"
 const deviceIpod = "ipod"
 const deviceIpad = "ipad"
-const deviceMacPpc = "macintosh" //Used for disambiguation
+const deviceMacPpc = "macintosh"    //Used for disambiguation
+const deviceMacintosh = "macintosh" //iPadOS 13+ Safari sends the Mac UA
+const engineSafari = "safari/"
+const engineVersion = "version/"
+const deviceTouch = "touch" //Windows tablets, BB10 and a few others name their touch screen
 
 const deviceAndroid = "android"
 const deviceGoogleTV = "googletv"
@@ -136,6 +137,23 @@ const deviceMeego = "meego"       //Meego OS
 const deviceSailfish = "sailfish" //Sailfish OS
 const deviceUbuntu = "ubuntu"     //Ubuntu Mobile OS
 
+const deviceChromeOS = "; cros"         //Chromebooks
+const deviceFireTablet = "; kf"         //Fire tablet models all start with KF, such as KFTT and KFMUWI
+const engineSilkFire = "silk/"          //Silk on Fire OS
+const deviceHarmonyOS = "harmonyos"     //Huawei HarmonyOS, which also claims Android
+const deviceOpenHarmony = "openharmony" //HarmonyOS NEXT, which doesn't
+const deviceKaiOS = "kaios"             //KaiOS feature phones
+
+//Foldable phones. The UA is the same folded and unfolded.
+var foldableTokens = []string{
+	"sm-f9", "sm-f7", //Samsung Galaxy Z Fold and Z Flip
+	"pixel fold",
+	"tah-an00", "tah-al00", "tet-an00", "tet-al00", "pal-al00", "alt-al00", //Huawei Mate X series
+	"mgi-an00",         //Honor Magic V
+	"phn110",           //Oppo Find N
+	"xt2000", "xt2071", //Motorola razr
+}
+
 const deviceKindle = "kindle"         //Amazon Kindle, eInk one
 const engineSilk = "silk-accelerated" //Amazon"s accelerated Silk browser for Kindle Fire
 
@@ -173,9 +191,34 @@ const mini = "mini"     //Some mobile browsers put "mini" in their names.
 const mobile = "mobile" //Some mobile browsers put "mobile" in their user agent strings.
 const mobi = "mobi"     //Some mobile browsers put "mobi" in their user agent strings.
 
-//Smart TV strings
-const smartTV1 = "smart-tv" //Samsung Tizen smart TVs
-const smartTV2 = "smarttv"  //LG WebOS smart TVs
+// Smart TV strings
+const smartTV1 = "smart-tv"  //Samsung Tizen smart TVs
+const smartTV2 = "smarttv"   //LG WebOS smart TVs
+const deviceFireTV = "; aft" //Fire TV models all start with AFT, such as AFTB and AFTMM
+const deviceRoku = "roku"
+const deviceChromecast = "crkey"
+const deviceHbbTV = "hbbtv"
+const deviceVidaa = "vidaa" //Hisense TVs
+
+var androidTVTokens = []string{"android tv", "androidtv", "bravia", "mibox"}
+var appleTVTokens = []string{"appletv", "apple tv", "tvos"}
+
+//Other TV platforms without a detection method of their own.
+var smartTVTokens = []string{smartTV1, smartTV2, "smart tv", "netcast", "viera", "philipstv", "nettv", "opera tv"}
+
+//Smartwatches. Most say "Mobile", and Wear OS ones also say "Android".
+var wearableTokens = []string{
+	"wear os", "wearos", "android wear",
+	"watch os", "watchos", "(watch;", //Apple Watch
+	"sm-r5", "sm-r6", "sm-r7", "sm-r8", "sm-r9", "sm-l3", "sm-l7", //Samsung Gear and Galaxy Watch
+	"pixel watch", "ticwatch",
+}
+
+//Car head units.
+var automotiveTokens = []string{"automotive", "tesla/", "qtcarbrowser", "polestar"}
+
+//Handheld consoles, which don't belong with the TVs.
+var handheldConsoleTokens = []string{"portable", "nintendo ds", "nintendo 3ds", deviceNintendoDs}
 
 //Use Maemo, Tablet, and Linux to test for Nokia"s Internet Tablets.
 const maemo = "maemo"
@@ -198,21 +241,70 @@ const svcVodafone = "vodafone"
 //Disambiguation strings.
 const disUpdate = "update" //pda vs. update
 
+//Crawlers, link preview fetchers and uptime monitors.
+//  Many of them claim to be an iPhone or Android phone as well.
+var botTokens = []string{
+	//Search engines
+	"googlebot", "adsbot-google", "mediapartners-google", "google-inspectiontool", "storebot-google",
+	"bingbot", "bingpreview", "adidxbot", "msnbot", "slurp", "duckduckbot", "baiduspider",
+	"yandexbot", "yandexmobilebot", "sogou web spider", "sogou inst spider", "exabot", "applebot", "petalbot", "seznambot",
+	//Social and messaging previews
+	"facebookexternalhit", "facebookcatalog", "facebot", "twitterbot", "linkedinbot", "pinterestbot",
+	"slackbot", "discordbot", "telegrambot", "whatsapp", "skypeuripreview", "redditbot", "embedly",
+	//SEO tools and monitoring
+	"ahrefsbot", "semrushbot", "mj12bot", "dotbot", "rogerbot", "pingdom", "uptimerobot",
+	"statuscake", "site24x7", "newrelicpinger", "datadogsynthetics", "gtmetrix", "chrome-lighthouse",
+	//Generic crawler names
+	"crawler", "spider",
+}
+
+//Alternate headers carrying the device User Agent, set by proxies and transcoders.
+//  Checked in order when the User-Agent header is empty.
+var AlternateUserAgentHeaders = []string{
+	"X-Device-User-Agent",
+	"X-OperaMini-Phone-UA",
+	"X-Original-User-Agent",
+}
+
 type headers struct {
+	userAgent        string //The User Agent as sent, for Model().
 	userAgentHeader  string
 	httpAcceptHeader string
+	clientHints      ClientHints
 }
 
+// The Is* fields hold the int results of the most popular detections.
 type devices struct {
-	initCompleted       int //Stores whether we're currently initializing the most popular func (base *UAgentInfo)s.
-	IsWebkit            int //Stores the result of DetectWebkit()
-	IsMobilePhone       int //Stores the result of DetectMobileQuick()
-	IsIphone            int //Stores the result of DetectIphone()
-	IsAndroid           int //Stores the result of DetectAndroid()
-	IsAndroidPhone      int //Stores the result of DetectAndroidPhone()
-	IsTierTablet        int //Stores the result of DetectTierTablet()
-	IsTierIphone        int //Stores the result of DetectTierIphone()
-	IsTierRichCss       int //Stores the result of DetectTierRichCss()
+	rules           *ruleset    //The rules in effect when the object was created.
+	results         []scanState //Stores the memoized result of every rule.
+	userAgentTokens bitset      //The rule tokens found in the User Agent.
+	acceptTokens    bitset      //The rule tokens found in the HTTP Accept value.
+
+	// Deprecated: Use Device().Webkit() instead.
+	IsWebkit int //Stores the result of DetectWebkit()
+
+	// Deprecated: Use Device().MobileQuick() instead.
+	IsMobilePhone int //Stores the result of DetectMobileQuick()
+
+	// Deprecated: Use Device().Iphone() instead.
+	IsIphone int //Stores the result of DetectIphone()
+
+	// Deprecated: Use Device().Android() instead.
+	IsAndroid int //Stores the result of DetectAndroid()
+
+	// Deprecated: Use Device().AndroidPhone() instead.
+	IsAndroidPhone int //Stores the result of DetectAndroidPhone()
+
+	// Deprecated: Use Device().TierTablet() instead.
+	IsTierTablet int //Stores the result of DetectTierTablet()
+
+	// Deprecated: Use Device().TierIphone() instead.
+	IsTierIphone int //Stores the result of DetectTierIphone()
+
+	// Deprecated: Use Device().TierRichCss() instead.
+	IsTierRichCss int //Stores the result of DetectTierRichCss()
+
+	// Deprecated: Use Device().TierOtherPhones() instead.
 	IsTierGenericMobile int //Stores the result of DetectTierOtherPhones()
 }
 
@@ -221,31 +313,58 @@ type UAgentInfo struct {
 	devices
 }
 
+// Device answers each detection with a bool. Obtain it from UAgentInfo.Device().
+type Device struct {
+	info        *UAgentInfo
+	excludeBots bool
+}
+
 //**************************
 //The constructor. Allows the latest PHP (5.0+) to locate a constructor object and initialize the object.
 func NewMDetect(request *http.Request) *UAgentInfo {
-	uAgent, httpAccept := uAgentInfo(request)
+	return NewFromHeader(request.Header)
+}
+
+//**************************
+//The constructor for callers without an *http.Request, such as batch jobs
+//  and log processors. Reads the User Agent, HTTP Accept and client hint values from header.
+func NewFromHeader(header http.Header) *UAgentInfo {
+	uAgent, httpAccept := uAgentInfo(header)
+	return NewFromHints(uAgent, httpAccept, ParseClientHints(header))
+}
 
+//**************************
+//The constructor for raw User Agent and HTTP Accept strings.
+//  Mirrors the UAgentInfo(String userAgent, String httpAccept) constructor of the Java port.
+func NewFromStrings(userAgent string, httpAccept string) *UAgentInfo {
+	return NewFromHints(userAgent, httpAccept, ClientHints{})
+}
+
+//**************************
+//The constructor for raw strings plus client hints. The hints win over the User Agent.
+func NewFromHints(userAgent string, httpAccept string, hints ClientHints) *UAgentInfo {
 	base := UAgentInfo{}
-	base.httpAcceptHeader = httpAccept
-	base.userAgentHeader = uAgent
+	base.httpAcceptHeader = strings.ToLower(httpAccept)
+	base.userAgent = userAgent
+	base.userAgentHeader = strings.ToLower(userAgent)
+	base.clientHints = hints
 
 	base.initDeviceScan()
 	return &base
 }
 
 //**************************
-//The object initializer. Initializes several default variables.
-func uAgentInfo(request *http.Request) (string, string) {
-	userAgentHeader := request.Header.Get("HTTP_USER_AGENT")
-	httpAcceptHeader := request.Header.Get("HTTP_ACCEPT")
+//The object initializer. Reads the raw User Agent and HTTP Accept values.
+//  Falls back to AlternateUserAgentHeaders when User-Agent is empty.
+func uAgentInfo(header http.Header) (string, string) {
+	userAgentHeader := header.Get("User-Agent")
+	httpAcceptHeader := header.Get("Accept")
 
-	if userAgentHeader != "" {
-		userAgentHeader = strings.ToLower(userAgentHeader)
-	}
-
-	if httpAcceptHeader != "" {
-		httpAcceptHeader = strings.ToLower(httpAcceptHeader)
+	for _, name := range AlternateUserAgentHeaders {
+		if userAgentHeader != "" {
+			break
+		}
+		userAgentHeader = header.Get(name)
 	}
 
 	return userAgentHeader, httpAcceptHeader
@@ -254,21 +373,31 @@ func uAgentInfo(request *http.Request) (string, string) {
 //**************************
 // Initialize Key Stored Values.
 func (base *UAgentInfo) initDeviceScan() {
-	base.IsWebkit = base.DetectWebkit()
-	base.IsIphone = base.DetectIphone()
-	base.IsAndroid = base.DetectAndroid()
-	base.IsAndroidPhone = base.DetectAndroidPhone()
+	device := base.Device()
+	base.IsWebkit = boolToInt(device.Webkit())
+	base.IsIphone = boolToInt(device.Iphone())
+	base.IsAndroid = boolToInt(device.Android())
+	base.IsAndroidPhone = boolToInt(device.AndroidPhone())
 
 	//These tiers are the most useful for web development
-	base.IsMobilePhone = base.DetectMobileQuick()
-	base.IsTierIphone = base.DetectTierIphone()
-	base.IsTierTablet = base.DetectTierTablet()
+	base.IsMobilePhone = boolToInt(device.MobileQuick())
+	base.IsTierIphone = boolToInt(device.TierIphone())
+	base.IsTierTablet = boolToInt(device.TierTablet())
 
 	//Optional: Comment these out if you NEVER use them.
-	base.IsTierRichCss = base.DetectTierRichCss()
-	base.IsTierGenericMobile = base.DetectTierOtherPhones()
+	base.IsTierRichCss = boolToInt(device.TierRichCss())
+	base.IsTierGenericMobile = boolToInt(device.TierOtherPhones())
+}
 
-	base.initCompleted = true
+//**************************
+//Returns a bool-typed view of the detection methods.
+//  For example, Device().TierIphone() reports the same result as DetectTierIphone().
+func (base *UAgentInfo) Device() Device {
+	if base.rules == nil {
+		base.rules = currentRules()
+		base.results = make([]scanState, len(base.rules.list))
+	}
+	return Device{info: base}
 }
 
 //**************************
@@ -285,82 +414,53 @@ func (base *UAgentInfo) GetHttpAccept() string {
 
 //*****************************
 // Start device detection
+//   Each method evaluates the rule of the same name, in lower case.
+//   The built-in rules are in rules_default.go.
 //*****************************
 
 //**************************
 // Detects if the current device is an iPhone.
-func (base *UAgentInfo) DetectIphone() int {
-	if base.initCompleted == true || base.IsIphone == true {
-		return base.IsIphone
-	}
-
-	if strings.Index(base.userAgentHeader, deviceIphone) > -1 {
-		//The iPad and iPod Touch say they're an iPhone. So let's disambiguate.
-		if base.DetectIpad() == true || base.DetectIpod() == true {
-			return false
-		} else {
-			//Yay! It's an iPhone!
-			return true
-		}
-	} else {
-		return false
-	}
+func (device Device) Iphone() bool {
+	return device.is(scanIphone)
 }
 
 //**************************
 // Detects if the current device is an iPod Touch.
-func (base *UAgentInfo) DetectIpod() int {
-	if strings.Index(base.userAgentHeader, deviceIpod) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Ipod() bool {
+	return device.is(scanIpod)
 }
 
 //**************************
 // Detects if the current device is an iPad tablet.
-func (base *UAgentInfo) DetectIpad() int {
-	if strings.Index(base.userAgentHeader, deviceIpad) > -1 && base.DetectWebkit() == true {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Ipad() bool {
+	return device.is(scanIpad)
+}
+
+//**************************
+// Detects an iPad whose Safari asks for desktop sites, as iPadOS 13+ does by default.
+//   Its UA is the one of Mac Safari, so this needs the TouchPoints client hint.
+//   DetectIpad() includes it.
+func (device Device) IpadDesktopMode() bool {
+	return device.is(scanIpadDesktopMode)
 }
 
 //**************************
 // Detects if the current device is an iPhone or iPod Touch.
-func (base *UAgentInfo) DetectIphoneOrIpod() int {
-	//We repeat the searches here because some iPods may report themselves as an iPhone, which would be okay.
-	if base.DetectIphone() == true || base.DetectIpod() == true {
-		return true
-	} else {
-		return false
-	}
+func (device Device) IphoneOrIpod() bool {
+	return device.is(scanIphoneOrIpod)
 }
 
 //**************************
 // Detects *any* iOS device: iPhone, iPod Touch, iPad.
-func (base *UAgentInfo) DetectIos() int {
-	if (base.DetectIphoneOrIpod() == true) || (base.DetectIpad() == true) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Ios() bool {
+	return device.is(scanIos)
 }
 
 //**************************
 // Detects *any* Android OS-based device: phone, tablet, and multi-media player.
 // Also detects Google TV.
-func (base *UAgentInfo) DetectAndroid() int {
-	if base.initCompleted == true || base.IsAndroid == true {
-		return base.IsAndroid
-	}
-
-	if (strings.Index(base.userAgentHeader, deviceAndroid) > -1) || (base.DetectGoogleTV() == true) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Android() bool {
+	return device.is(scanAndroid)
 }
 
 //**************************
@@ -368,212 +468,166 @@ func (base *UAgentInfo) DetectAndroid() int {
 // used for calling and/or multi-media (like a Samsung Galaxy Player).
 // Google says these devices will have 'Android' AND 'mobile' in user agent.
 // Ignores tablets (Honeycomb and later).
-func (base *UAgentInfo) DetectAndroidPhone() int {
-	if base.initCompleted == true || base.IsAndroidPhone == true {
-		return base.IsAndroidPhone
-	}
-
-	//First, let's make sure we're on an Android device.
-	if base.DetectAndroid() == false {
-		return false
-	}
-
-	//If it's Android and has 'mobile' in it, Google says it's a phone.
-	if strings.Index(base.userAgentHeader, mobile) > -1 {
-		return true
-	}
-	//Special check for Android devices with Opera Mobile/Mini. They should report here.
-	if base.DetectOperaMobile() == true {
-		return true
-	} else {
-		return false
-	}
+func (device Device) AndroidPhone() bool {
+	return device.is(scanAndroidPhone)
 }
 
 //**************************
 // Detects if the current device is a (self-reported) Android tablet.
 // Google says these devices will have 'Android' and NOT 'mobile' in their user agent.
-func (base *UAgentInfo) DetectAndroidTablet() int {
-	//First, let's make sure we're on an Android device.
-	if base.DetectAndroid() == false {
-		return false
-	}
-
-	//Special check for Android devices with Opera Mobile/Mini. They should NOT report here.
-	if base.DetectOperaMobile() == true {
-		return false
-	}
-
-	//Otherwise, if it's Android and does NOT have 'mobile' in it, Google says it's a tablet.
-	if strings.Index(base.userAgentHeader, mobile) > -1 {
-		return false
-	} else {
-		return true
-	}
+func (device Device) AndroidTablet() bool {
+	return device.is(scanAndroidTablet)
 }
 
 //**************************
 // Detects if the current device is an Android OS-based device and
 //   the browser is based on WebKit.
-func (base *UAgentInfo) DetectAndroidWebKit() int {
-	if (base.DetectAndroid() == true) && (base.DetectWebkit() == true) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) AndroidWebKit() bool {
+	return device.is(scanAndroidWebKit)
 }
 
 //**************************
 // Detects if the current device is a GoogleTV.
-func (base *UAgentInfo) DetectGoogleTV() int {
-	if strings.Index(base.userAgentHeader, deviceGoogleTV) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) GoogleTV() bool {
+	return device.is(scanGoogleTV)
 }
 
 //**************************
-// Detects if the current browser is based on WebKit.
-func (base *UAgentInfo) DetectWebkit() int {
-	if base.initCompleted == true || base.IsWebkit == true {
-		return base.IsWebkit
-	}
+// Detects a smart TV, streaming stick, set-top box or game console:
+//   anything used from the couch. Handheld consoles are left out.
+func (device Device) SmartTV() bool {
+	return device.is(scanSmartTV)
+}
 
-	if strings.Index(base.userAgentHeader, engineWebKit) > -1 {
-		return true
-	} else {
-		return false
-	}
+//**************************
+// Detects an Android TV or Google TV device, such as the Nvidia Shield or a Sony Bravia.
+func (device Device) AndroidTV() bool {
+	return device.is(scanAndroidTV)
+}
+
+//**************************
+// Detects an Amazon Fire TV. It has no browser, only WebViews in apps.
+func (device Device) FireTV() bool {
+	return device.is(scanFireTV)
+}
+
+//**************************
+// Detects a Roku player or TV. It has no browser, only WebViews in apps.
+func (device Device) RokuTV() bool {
+	return device.is(scanRokuTV)
+}
+
+//**************************
+// Detects an Apple TV.
+func (device Device) AppleTV() bool {
+	return device.is(scanAppleTV)
+}
+
+//**************************
+// Detects a Chromecast, including Chromecast with Google TV.
+func (device Device) Chromecast() bool {
+	return device.is(scanChromecast)
+}
+
+//**************************
+// Detects a TV that runs HbbTV, the European hybrid broadcast standard.
+func (device Device) HbbTV() bool {
+	return device.is(scanHbbTV)
+}
+
+//**************************
+// Detects a Hisense TV running Vidaa OS.
+func (device Device) Vidaa() bool {
+	return device.is(scanVidaa)
+}
+
+//**************************
+// Detects a smartwatch: Wear OS, Apple Watch or a Tizen Galaxy watch.
+//   Watches are not in the phone tiers or DetectMobileQuick().
+func (device Device) Wearable() bool {
+	return device.is(scanWearable)
+}
+
+//**************************
+// Detects the browser of a car head unit, such as Android Automotive or a Tesla.
+//   Cars are not in the phone or tablet tiers or DetectMobileQuick().
+func (device Device) Automotive() bool {
+	return device.is(scanAutomotive)
+}
+
+//**************************
+// Detects if the current browser is based on WebKit.
+func (device Device) Webkit() bool {
+	return device.is(scanWebkit)
 }
 
 //**************************
 // Detects if the current browser is a
 // Windows Phone 7, 8, or 10 device.
-func (base *UAgentInfo) DetectWindowsPhone() int {
-	if (base.DetectWindowsPhone7() == true) || (base.DetectWindowsPhone8() == true) || (base.DetectWindowsPhone10() == true) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) WindowsPhone() bool {
+	return device.is(scanWindowsPhone)
 }
 
 //**************************
 // Detects a Windows Phone 7 device (in mobile browsing mode).
-func (base *UAgentInfo) DetectWindowsPhone7() int {
-	if strings.Index(base.userAgentHeader, deviceWinPhone7) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) WindowsPhone7() bool {
+	return device.is(scanWindowsPhone7)
 }
 
 //**************************
 // Detects a Windows Phone 8 device (in mobile browsing mode).
-func (base *UAgentInfo) DetectWindowsPhone8() int {
-	if strings.Index(base.userAgentHeader, deviceWinPhone8) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) WindowsPhone8() bool {
+	return device.is(scanWindowsPhone8)
 }
 
 //**************************
 // Detects a Windows Phone 10 device (in mobile browsing mode).
-func (base *UAgentInfo) DetectWindowsPhone10() int {
-	if strings.Index(base.userAgentHeader, deviceWinPhone10) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) WindowsPhone10() bool {
+	return device.is(scanWindowsPhone10)
 }
 
 //**************************
 // Detects if the current browser is a Windows Mobile device.
 // Excludes Windows Phone 7 and later devices.
 // Focuses on Windows Mobile 6.xx and earlier.
-func (base *UAgentInfo) DetectWindowsMobile() int {
-	if base.DetectWindowsPhone() == true {
-		return false
-	}
-
-	//Most devices use 'Windows CE', but some report 'iemobile'
-	//  and some older ones report as 'PIE' for Pocket IE.
-	if strings.Index(base.userAgentHeader, deviceWinMob) > -1 || strings.Index(base.userAgentHeader, deviceIeMob) > -1 ||
-		strings.Index(base.userAgentHeader, enginePie) > -1 {
-		return true
-	} //Test for Windows Mobile PPC but not old Macintosh PowerPC.
-	if strings.Index(base.userAgentHeader, devicePpc) > -1 && !(strings.Index(base.userAgentHeader, deviceMacPpc) > 1) {
-		return true
-	} //Test for certain Windwos Mobile-based HTC devices.
-	if strings.Index(base.userAgentHeader, manuHtc) > -1 && strings.Index(base.userAgentHeader, deviceWindows) > -1 {
-		return true
-	}
-	if base.DetectWapWml() == true && strings.Index(base.userAgentHeader, deviceWindows) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) WindowsMobile() bool {
+	return device.is(scanWindowsMobile)
 }
 
 //**************************
 // Detects if the current browser is any BlackBerry device.
 // Includes BB10 OS, but excludes the PlayBook.
-func (base *UAgentInfo) DetectBlackBerry() int {
-	if (strings.Index(base.userAgentHeader, deviceBB) > -1) || (strings.Index(base.httpAcceptHeader, vndRIM) > -1) {
-		return true
-	}
-	if base.DetectBlackBerry10Phone() == true {
-		return true
-	} else {
-		return false
-	}
+func (device Device) BlackBerry() bool {
+	return device.is(scanBlackBerry)
 }
 
 //**************************
 // Detects if the current browser is a BlackBerry 10 OS phone.
 // Excludes tablets.
-func (base *UAgentInfo) DetectBlackBerry10Phone() int {
-	if (strings.Index(base.userAgentHeader, deviceBB10) > -1) && (strings.Index(base.userAgentHeader, mobile) > -1) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) BlackBerry10Phone() bool {
+	return device.is(scanBlackBerry10Phone)
 }
 
 //**************************
 // Detects if the current browser is on a BlackBerry tablet device.
 //    Examples: PlayBook
-func (base *UAgentInfo) DetectBlackBerryTablet() int {
-	if strings.Index(base.userAgentHeader, deviceBBPlaybook) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) BlackBerryTablet() bool {
+	return device.is(scanBlackBerryTablet)
 }
 
 //**************************
 // Detects if the current browser is a BlackBerry phone device AND uses a
 //    WebKit-based browser. These are signatures for the new BlackBerry OS 6.
 //    Examples: Torch. Includes the Playbook.
-func (base *UAgentInfo) DetectBlackBerryWebKit() int {
-	if (base.DetectBlackBerry() == true) && (base.DetectWebkit() == true) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) BlackBerryWebKit() bool {
+	return device.is(scanBlackBerryWebKit)
 }
 
 //**************************
 // Detects if the current browser is a BlackBerry Touch phone device with
 //    a large screen, such as the Storm, Torch, and Bold Touch. Excludes the Playbook.
-func (base *UAgentInfo) DetectBlackBerryTouch() int {
-	if (strings.Index(base.userAgentHeader, deviceBBStorm) > -1) || (strings.Index(base.userAgentHeader, deviceBBTorch) > -1) ||
-		(strings.Index(base.userAgentHeader, deviceBBBoldTouch) > -1) || (strings.Index(base.userAgentHeader, deviceBBCurveTouch) > -1) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) BlackBerryTouch() bool {
+	return device.is(scanBlackBerryTouch)
 }
 
 //**************************
@@ -581,440 +635,309 @@ func (base *UAgentInfo) DetectBlackBerryTouch() int {
 //    has a more capable recent browser. Excludes the Playbook.
 //    Examples, Storm, Bold, Tour, Curve2
 //    Excludes the new BlackBerry OS 6 and 7 browser!!
-func (base *UAgentInfo) DetectBlackBerryHigh() int {
-	//Disambiguate for BlackBerry OS 6 or 7 (WebKit) browser
-	if base.DetectBlackBerryWebKit() == true {
-		return false
-	}
-	if base.DetectBlackBerry() == true {
-		if (base.DetectBlackBerryTouch() == true) || strings.Index(base.userAgentHeader, deviceBBBold) > -1 ||
-			strings.Index(base.userAgentHeader, deviceBBTour) > -1 || strings.Index(base.userAgentHeader, deviceBBCurve) > -1 {
-			{
-				return true
-			}
-		} else {
-			return false
-		}
-	} else {
-		return false
-	}
+func (device Device) BlackBerryHigh() bool {
+	return device.is(scanBlackBerryHigh)
 }
 
 //**************************
 // Detects if the current browser is a BlackBerry device AND
 //    has an older, less capable browser.
 //    Examples: Pearl, 8800, Curve1.
-func (base *UAgentInfo) DetectBlackBerryLow() int {
-	if base.DetectBlackBerry() == true {
-		//Assume that if it's not in the High tier, then it's Low.
-		if (base.DetectBlackBerryHigh() == true) || (base.DetectBlackBerryWebKit() == true) {
-			return false
-		} else {
-			return true
-		}
-	} else {
-		return false
-	}
+func (device Device) BlackBerryLow() bool {
+	return device.is(scanBlackBerryLow)
 }
 
 //**************************
 // Detects if the current browser is the Nokia S60 Open Source Browser.
-func (base *UAgentInfo) DetectS60OssBrowser() int {
-	//First, test for WebKit, then make sure it's either Symbian or S60.
-	if base.DetectWebkit() == true {
-		if strings.Index(base.userAgentHeader, deviceSymbian) > -1 || strings.Index(base.userAgentHeader, deviceS60) > -1 {
-			{
-				return true
-			}
-		} else {
-			return false
-		}
-	} else {
-		return false
-	}
+func (device Device) S60OssBrowser() bool {
+	return device.is(scanS60OssBrowser)
 }
 
 //**************************
 // Detects if the current device is any Symbian OS-based device,
 //   including older S60, Series 70, Series 80, Series 90, and UIQ,
 //   or other browsers running on these devices.
-func (base *UAgentInfo) DetectSymbianOS() int {
-	if strings.Index(base.userAgentHeader, deviceSymbian) > -1 || strings.Index(base.userAgentHeader, deviceS60) > -1 ||
-		strings.Index(base.userAgentHeader, deviceS70) > -1 || strings.Index(base.userAgentHeader, deviceS80) > -1 ||
-		strings.Index(base.userAgentHeader, deviceS90) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) SymbianOS() bool {
+	return device.is(scanSymbianOS)
 }
 
 //**************************
 // Detects if the current browser is on a PalmOS device.
-func (base *UAgentInfo) DetectPalmOS() int {
-	//Most devices nowadays report as 'Palm', but some older ones reported as Blazer or Xiino.
-	if strings.Index(base.userAgentHeader, devicePalm) > -1 ||
-		strings.Index(base.userAgentHeader, engineBlazer) > -1 ||
-		strings.Index(base.userAgentHeader, engineXiino) > -1 {
-		//Make sure it's not WebOS first
-		if base.DetectPalmWebOS() == true {
-			return false
-		} else {
-			return true
-		}
-	} else {
-		return false
-	}
+func (device Device) PalmOS() bool {
+	return device.is(scanPalmOS)
 }
 
 //**************************
 // Detects if the current browser is on a Palm device
 //   running the new WebOS.
-func (base *UAgentInfo) DetectPalmWebOS() int {
-	if strings.Index(base.userAgentHeader, deviceWebOS) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) PalmWebOS() bool {
+	return device.is(scanPalmWebOS)
 }
 
 //**************************
 // Detects if the current browser is on an HP tablet running WebOS.
-func (base *UAgentInfo) DetectWebOSTablet() int {
-	if (strings.Index(base.userAgentHeader, deviceWebOShp) > -1) && (strings.Index(base.userAgentHeader, deviceTablet) > -1) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) WebOSTablet() bool {
+	return device.is(scanWebOSTablet)
 }
 
 //**************************
 // Detects if the current browser is on a WebOS smart TV.
-func (base *UAgentInfo) DetectWebOSTV() int {
-	if (strings.Index(base.userAgentHeader, deviceWebOStv) > -1) && (strings.Index(base.userAgentHeader, smartTV2) > -1) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) WebOSTV() bool {
+	return device.is(scanWebOSTV)
 }
 
 //**************************
 // Detects if the current browser is Opera Mobile or Mini.
-func (base *UAgentInfo) DetectOperaMobile() int {
-	if (strings.Index(base.userAgentHeader, engineOpera) > -1) &&
-		((strings.Index(base.userAgentHeader, mini) > -1) ||
-			(strings.Index(base.userAgentHeader, mobi) > -1)) {
-		return true
-	}
-	return false
+func (device Device) OperaMobile() bool {
+	return device.is(scanOperaMobile)
 }
 
 //**************************
 // Detects if the current device is an Amazon Kindle (eInk devices only).
-// Note: For the Kindle Fire, use the normal Android methods.
-func (base *UAgentInfo) DetectKindle() int {
-	if strings.Index(base.userAgentHeader, deviceKindle) > -1 &&
-		base.DetectAndroid() == false {
-		return true
-	} else {
-		return false
-	}
+// Note: For the Kindle Fire, use DetectFireTablet() or the normal Android methods.
+func (device Device) Kindle() bool {
+	return device.is(scanKindle)
 }
 
 //**************************
 // Detects if the current Amazon device has turned on the Silk accelerated browsing feature.
 // Note: Typically used by the the Kindle Fire.
-func (base *UAgentInfo) DetectAmazonSilk() int {
-	if strings.Index(base.userAgentHeader, engineSilk) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) AmazonSilk() bool {
+	return device.is(scanAmazonSilk)
+}
+
+//**************************
+// Detects Amazon Fire OS, which is based on Android: Fire tablets and the Fire Phone.
+func (device Device) FireOS() bool {
+	return device.is(scanFireOS)
+}
+
+//**************************
+// Detects an Amazon Fire tablet, including the Kindle Fire.
+//   Silk sends "Mobile" on them too, so they don't pass DetectAndroidTablet().
+func (device Device) FireTablet() bool {
+	return device.is(scanFireTablet)
 }
 
 //**************************
 // Detects if a Garmin Nuvifone device.
-func (base *UAgentInfo) DetectGarminNuvifone() int {
-	if strings.Index(base.userAgentHeader, deviceNuvifone) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) GarminNuvifone() bool {
+	return device.is(scanGarminNuvifone)
 }
 
 //**************************
 // Detects a device running the Bada OS from Samsung.
-func (base *UAgentInfo) DetectBada() int {
-	if strings.Index(base.userAgentHeader, deviceBada) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Bada() bool {
+	return device.is(scanBada)
 }
 
 //**************************
 // Detects a device running the Tizen smartphone OS.
-func (base *UAgentInfo) DetectTizen() int {
-	if strings.Index(base.userAgentHeader, deviceTizen) > -1 && (strings.Index(base.userAgentHeader, mobile) > -1) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Tizen() bool {
+	return device.is(scanTizen)
 }
 
 //**************************
 // Detects if the current browser is on a Tizen smart TV.
-func (base *UAgentInfo) DetectTizenTV() int {
-	if (strings.Index(base.userAgentHeader, deviceTizen) > -1) && (strings.Index(base.userAgentHeader, smartTV1) > -1) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) TizenTV() bool {
+	return device.is(scanTizenTV)
 }
 
 //**************************
 // Detects a device running the Meego OS.
-func (base *UAgentInfo) DetectMeego() int {
-	if strings.Index(base.userAgentHeader, deviceMeego) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Meego() bool {
+	return device.is(scanMeego)
 }
 
 //**************************
 // Detects a phone running the Meego OS.
-func (base *UAgentInfo) DetectMeegoPhone() int {
-	if (strings.Index(base.userAgentHeader, deviceMeego) > -1) && (strings.Index(base.userAgentHeader, mobi) > -1) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) MeegoPhone() bool {
+	return device.is(scanMeegoPhone)
 }
 
 //**************************
 // Detects a mobile device (probably) running the Firefox OS.
-func (base *UAgentInfo) DetectFirefoxOS() int {
-	if (base.DetectFirefoxOSPhone() == true) || (base.DetectFirefoxOSTablet() == true) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) FirefoxOS() bool {
+	return device.is(scanFirefoxOS)
 }
 
 //**************************
 // Detects a phone (probably) running the Firefox OS.
-func (base *UAgentInfo) DetectFirefoxOSPhone() int {
-	//First, let's make sure we're NOT on another major mobile OS.
-	if base.DetectIos() == true || base.DetectAndroid() == true || base.DetectSailfish() == true {
-		return false
-	}
-
-	if (strings.Index(base.userAgentHeader, engineFirefox) > -1) &&
-		(strings.Index(base.userAgentHeader, mobile) > -1) {
-		return true
-	}
-	return false
+func (device Device) FirefoxOSPhone() bool {
+	return device.is(scanFirefoxOSPhone)
 }
 
 //**************************
 // Detects a tablet (probably) running the Firefox OS.
-func (base *UAgentInfo) DetectFirefoxOSTablet() int {
-	//First, let's make sure we're NOT on another major mobile OS.
-	if base.DetectIos() == true || base.DetectAndroid() == true || base.DetectSailfish() == true {
-		return false
-	}
-
-	if (strings.Index(base.userAgentHeader, engineFirefox) > -1) &&
-		(strings.Index(base.userAgentHeader, deviceTablet) > -1) {
-		return true
-	}
-	return false
+func (device Device) FirefoxOSTablet() bool {
+	return device.is(scanFirefoxOSTablet)
 }
 
 //**************************
 // Detects a device running the Sailfish OS.
-func (base *UAgentInfo) DetectSailfish() int {
-	if strings.Index(base.userAgentHeader, deviceSailfish) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Sailfish() bool {
+	return device.is(scanSailfish)
 }
 
 //**************************
 // Detects a phone running the Sailfish OS.
-func (base *UAgentInfo) DetectSailfishPhone() int {
-	if (base.DetectSailfish() == true) &&
-		(strings.Index(base.userAgentHeader, mobile) > -1) {
-		return true
-	}
-	return false
+func (device Device) SailfishPhone() bool {
+	return device.is(scanSailfishPhone)
 }
 
 //**************************
 // Detects a mobile device running the Ubuntu Mobile OS.
-func (base *UAgentInfo) DetectUbuntu() int {
-	if (base.DetectUbuntuPhone() == true) || (base.DetectUbuntuTablet() == true) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Ubuntu() bool {
+	return device.is(scanUbuntu)
 }
 
 //**************************
 // Detects a phone running the Ubuntu Mobile OS.
-func (base *UAgentInfo) DetectUbuntuPhone() int {
-	if (strings.Index(base.userAgentHeader, deviceUbuntu) > -1) && (strings.Index(base.userAgentHeader, mobile) > -1) {
-		return true
-	}
-	return false
+func (device Device) UbuntuPhone() bool {
+	return device.is(scanUbuntuPhone)
 }
 
 //**************************
 // Detects a tablet running the Ubuntu Mobile OS.
-func (base *UAgentInfo) DetectUbuntuTablet() int {
-	if (strings.Index(base.userAgentHeader, deviceUbuntu) > -1) &&
-		(strings.Index(base.userAgentHeader, deviceTablet) > -1) {
-		return true
-	}
-	return false
+func (device Device) UbuntuTablet() bool {
+	return device.is(scanUbuntuTablet)
+}
+
+//**************************
+// Detects Huawei HarmonyOS. Older versions also pass DetectAndroid().
+func (device Device) HarmonyOS() bool {
+	return device.is(scanHarmonyOS)
+}
+
+//**************************
+// Detects a phone running HarmonyOS.
+func (device Device) HarmonyOSPhone() bool {
+	return device.is(scanHarmonyOSPhone)
+}
+
+//**************************
+// Detects a tablet running HarmonyOS.
+func (device Device) HarmonyOSTablet() bool {
+	return device.is(scanHarmonyOSTablet)
+}
+
+//**************************
+// Detects a KaiOS feature phone. Its browser is modern, but the screen
+//   is small and there is no touch screen, so it is in the Rich CSS tier.
+func (device Device) KaiOS() bool {
+	return device.is(scanKaiOS)
+}
+
+//**************************
+// Detects a Chromebook.
+func (device Device) ChromeOS() bool {
+	return device.is(scanChromeOS)
+}
+
+//**************************
+// Detects a foldable phone, such as the Galaxy Z Fold or Pixel Fold.
+//   The tier follows the UA: "Mobile" puts it in the iPhone tier.
+func (device Device) Foldable() bool {
+	return device.is(scanFoldable)
 }
 
 //**************************
 // Detects the Danger Hiptop device.
-func (base *UAgentInfo) DetectDangerHiptop() int {
-	if strings.Index(base.userAgentHeader, deviceDanger) > -1 ||
-		strings.Index(base.userAgentHeader, deviceHiptop) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) DangerHiptop() bool {
+	return device.is(scanDangerHiptop)
 }
 
 //**************************
 // Detects if the current browser is a Sony Mylo device.
-func (base *UAgentInfo) DetectSonyMylo() int {
-	if (strings.Index(base.userAgentHeader, manuSony) > -1) &&
-		((strings.Index(base.userAgentHeader, qtembedded) > -1) ||
-			(strings.Index(base.userAgentHeader, mylocom2) > -1)) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) SonyMylo() bool {
+	return device.is(scanSonyMylo)
 }
 
 //**************************
 // Detects if the current device is on one of the Maemo-based Nokia Internet Tablets.
-func (base *UAgentInfo) DetectMaemoTablet() int {
-	if strings.Index(base.userAgentHeader, maemo) > -1 {
-		return true
-	} //For Nokia N810, must be Linux + Tablet, or else it could be something else.
-	if (strings.Index(base.userAgentHeader, linux) > -1) && (strings.Index(base.userAgentHeader, deviceTablet) > -1) && (base.DetectWebOSTablet() == false) && (base.DetectAndroid() == false) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) MaemoTablet() bool {
+	return device.is(scanMaemoTablet)
 }
 
 //**************************
 // Detects if the current device is an Archos media player/Internet tablet.
-func (base *UAgentInfo) DetectArchos() int {
-	if strings.Index(base.userAgentHeader, deviceArchos) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Archos() bool {
+	return device.is(scanArchos)
 }
 
 //**************************
 // Detects if the current device is an Internet-capable game console.
 // Includes many handheld consoles.
-func (base *UAgentInfo) DetectGameConsole() int {
-	if base.DetectSonyPlaystation() == true {
-		return true
-	} else if base.DetectNintendo() == true {
-		return true
-	} else if base.DetectXbox() == true {
-		return true
-	} else {
-		return false
-	}
+func (device Device) GameConsole() bool {
+	return device.is(scanGameConsole)
 }
 
 //**************************
 // Detects if the current device is a Sony Playstation.
-func (base *UAgentInfo) DetectSonyPlaystation() int {
-	if strings.Index(base.userAgentHeader, devicePlaystation) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) SonyPlaystation() bool {
+	return device.is(scanSonyPlaystation)
 }
 
 //**************************
 // Detects if the current device is a handheld gaming device with
 // a touchscreen and modern iPhone-class browser. Includes the Playstation Vita.
-func (base *UAgentInfo) DetectGamingHandheld() int {
-	if (strings.Index(base.userAgentHeader, devicePlaystation) > -1) &&
-		(strings.Index(base.userAgentHeader, devicePlaystationVita) > -1) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) GamingHandheld() bool {
+	return device.is(scanGamingHandheld)
 }
 
 //**************************
 // Detects if the current device is a Nintendo game device.
-func (base *UAgentInfo) DetectNintendo() int {
-	if strings.Index(base.userAgentHeader, deviceNintendo) > -1 ||
-		strings.Index(base.userAgentHeader, deviceWii) > -1 ||
-		strings.Index(base.userAgentHeader, deviceNintendoDs) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Nintendo() bool {
+	return device.is(scanNintendo)
 }
 
 //**************************
 // Detects if the current device is a Microsoft Xbox.
-func (base *UAgentInfo) DetectXbox() int {
-	if strings.Index(base.userAgentHeader, deviceXbox) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Xbox() bool {
+	return device.is(scanXbox)
 }
 
 //**************************
 // Detects whether the device is a Brew-powered device.
-func (base *UAgentInfo) DetectBrewDevice() int {
-	if strings.Index(base.userAgentHeader, deviceBrew) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) BrewDevice() bool {
+	return device.is(scanBrewDevice)
 }
 
 //**************************
 // Detects whether the device supports WAP or WML.
-func (base *UAgentInfo) DetectWapWml() int {
-	if strings.Index(base.httpAcceptHeader, vndwap) > -1 ||
-		strings.Index(base.httpAcceptHeader, wml) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) WapWml() bool {
+	return device.is(scanWapWml)
 }
 
 //**************************
 // Detects if the current device supports MIDP, a mobile Java technology.
-func (base *UAgentInfo) DetectMidpCapable() int {
-	if strings.Index(base.userAgentHeader, deviceMidp) > -1 ||
-		strings.Index(base.httpAcceptHeader, deviceMidp) > -1 {
-		return true
-	} else {
-		return false
-	}
+func (device Device) MidpCapable() bool {
+	return device.is(scanMidpCapable)
+}
+
+//**************************
+// Detects search engine crawlers, link preview fetchers and uptime monitors.
+func (device Device) Bot() bool {
+	return device.is(scanBot)
+}
+
+//**************************
+// Detects a crawler that presents itself as a mobile device,
+//   such as Googlebot smartphone or the mobile AdsBot.
+func (device Device) MobileBot() bool {
+	return device.is(scanMobileBot)
+}
+
+//**************************
+// Returns a view whose tier and mobile methods report false for bots,
+//   so crawlers don't count as phones or tablets.
+func (device Device) ExcludingBots() Device {
+	device.excludeBots = true
+	return device
+}
+
+func (device Device) excludedBot() bool {
+	return device.excludeBots && device.Bot()
 }
 
 //*****************************
@@ -1024,54 +947,19 @@ func (base *UAgentInfo) DetectMidpCapable() int {
 //**************************
 // Check to see whether the device is *any* 'smartphone'.
 //   Note: It's better to use DetectTierIphone() for modern touchscreen devices.
-func (base *UAgentInfo) DetectSmartphone() int {
-	//Exclude duplicates from TierIphone
-	if (base.DetectTierIphone() == true) || (base.DetectS60OssBrowser() == true) || (base.DetectSymbianOS() == true) ||
-		(base.DetectWindowsMobile() == true) || (base.DetectBlackBerry() == true) || (base.DetectMeegoPhone() == true) ||
-		(base.DetectPalmWebOS() == true) {
-		return true
-	} else {
-		return false
-	}
+func (device Device) Smartphone() bool {
+	return device.is(scanSmartphone)
 }
 
 //**************************
 // The quick way to detect for a mobile device.
 //   Will probably detect most recent/current mid-tier Feature Phones
 //   as well as smartphone-class devices. Excludes Apple iPads and other modern tablets.
-func (base *UAgentInfo) DetectMobileQuick() int {
-	if base.initCompleted == true || base.IsMobilePhone == true {
-		return base.IsMobilePhone
-	}
-
-	//Let's exclude tablets
-	if base.IsTierTablet == true {
+func (device Device) MobileQuick() bool {
+	if device.excludedBot() {
 		return false
 	}
-
-	//Most mobile browsing is done on smartphones
-	if base.DetectSmartphone() == true {
-		return true
-	}
-	//Catch-all for many mobile devices
-	if strings.Index(base.userAgentHeader, mobile) > -1 {
-		return true
-	}
-	if base.DetectOperaMobile() == true {
-		return true
-	}
-	//We also look for Kindle devices
-	if base.DetectKindle() == true ||
-		base.DetectAmazonSilk() == true {
-		return true
-	}
-	if (base.DetectWapWml() == true) || (base.DetectMidpCapable() == true) || (base.DetectBrewDevice() == true) {
-		return true
-	}
-	if (strings.Index(base.userAgentHeader, engineNetfront) > -1) || (strings.Index(base.userAgentHeader, engineUpBrowser) > -1) {
-		return true
-	}
-	return false
+	return device.is(scanMobileQuick)
 }
 
 //**************************
@@ -1081,28 +969,11 @@ func (base *UAgentInfo) DetectMobileQuick() int {
 //   Internet-enabled game consoles, etc.
 //   This ought to catch a lot of the more obscure and older devices, also --
 //   but no promises on thoroughness!
-func (base *UAgentInfo) DetectMobileLong() int {
-	if base.DetectMobileQuick() == true {
-		return true
-	}
-	if base.DetectGameConsole() == true {
-		return true
-	}
-	if (base.DetectDangerHiptop() == true) || (base.DetectMaemoTablet() == true) || (base.DetectSonyMylo() == true) ||
-		(base.DetectArchos() == true) {
-		return true
-	}
-	if (strings.Index(base.userAgentHeader, devicePda) > -1) && !(strings.Index(base.userAgentHeader, disUpdate) > -1) {
-		return true
-	}
-	//Detect older phones from certain manufacturers and operators.
-	if (strings.Index(base.userAgentHeader, uplink) > -1) || (strings.Index(base.userAgentHeader, engineOpenWeb) > -1) ||
-		(strings.Index(base.userAgentHeader, manuSamsung1) > -1) || (strings.Index(base.userAgentHeader, manuSonyEricsson) > -1) ||
-		(strings.Index(base.userAgentHeader, manuericsson) > -1) || (strings.Index(base.userAgentHeader, svcDocomo) > -1) ||
-		(strings.Index(base.userAgentHeader, svcKddi) > -1) || (strings.Index(base.userAgentHeader, svcVodafone) > -1) {
-		return true
+func (device Device) MobileLong() bool {
+	if device.excludedBot() {
+		return false
 	}
-	return false
+	return device.is(scanMobileLong)
 }
 
 //*****************************
@@ -1114,17 +985,23 @@ func (base *UAgentInfo) DetectMobileLong() int {
 //   This method detects for the new generation of
 //   HTML 5 capable, larger screen tablets.
 //   Includes iPad, Android (e.g., Xoom), BB Playbook, WebOS, etc.
-func (base *UAgentInfo) DetectTierTablet() int {
-	if base.initCompleted == true || base.IsTierTablet == true {
-		return base.IsTierTablet
+func (device Device) TierTablet() bool {
+	if device.excludedBot() {
+		return false
 	}
+	return device.is(scanTierTablet)
+}
 
-	if (base.DetectIpad() == true) || (base.DetectAndroidTablet() == true) || (base.DetectBlackBerryTablet() == true) ||
-		(base.DetectFirefoxOSTablet() == true) || (base.DetectUbuntuTablet() == true) || (base.DetectWebOSTablet() == true) {
-		return true
-	} else {
+//**************************
+// The quick way to detect for a tier of devices.
+//   This method detects for TVs, streaming sticks and game consoles,
+//   which need a "10-foot" UI: large type, and navigation by remote or gamepad.
+//   Includes Android TV, Fire TV, Roku, Apple TV, Tizen and WebOS TVs, Xbox, etc.
+func (device Device) TierTenFoot() bool {
+	if device.excludedBot() {
 		return false
 	}
+	return device.is(scanTierTenFoot)
 }
 
 //**************************
@@ -1132,24 +1009,11 @@ func (base *UAgentInfo) DetectTierTablet() int {
 //   This method detects for devices which can
 //   display iPhone-optimized web content.
 //   Includes iPhone, iPod Touch, Android, Windows Phone, BB10, Playstation Vita, etc.
-func (base *UAgentInfo) DetectTierIphone() int {
-	if base.initCompleted == true || base.IsTierIphone == true {
-		return base.IsTierIphone
-	}
-
-	if (base.DetectIphoneOrIpod() == true) || (base.DetectAndroidPhone() == true) || (base.DetectWindowsPhone() == true) ||
-		(base.DetectBlackBerry10Phone() == true) || (base.DetectPalmWebOS() == true) || (base.DetectBada() == true) ||
-		(base.DetectTizen() == true) || (base.DetectFirefoxOSPhone() == true) || (base.DetectSailfishPhone() == true) ||
-		(base.DetectUbuntuPhone() == true) || (base.DetectGamingHandheld() == true) {
-		return true
-	}
-	//Note: BB10 phone is in the previous paragraph
-	if (base.DetectBlackBerryWebKit() == true) &&
-		(base.DetectBlackBerryTouch() == true) {
-		return true
-	} else {
+func (device Device) TierIphone() bool {
+	if device.excludedBot() {
 		return false
 	}
+	return device.is(scanTierIphone)
 }
 
 //**************************
@@ -1158,58 +1022,20 @@ func (base *UAgentInfo) DetectTierIphone() int {
 //   of viewing CSS content optimized for the iPhone,
 //   but may not necessarily support JavaScript.
 //   Excludes all iPhone Tier devices.
-func (base *UAgentInfo) DetectTierRichCss() int {
-	if base.initCompleted == true || base.IsTierRichCss == true {
-		return base.IsTierRichCss
-	}
-
-	if base.DetectMobileQuick() == true {
-		//Exclude iPhone Tier and e-Ink Kindle devices
-		if (base.DetectTierIphone() == true) || (base.DetectKindle() == true) {
-			return false
-		}
-
-		//The following devices are explicitly ok.
-		if base.DetectWebkit() == true {
-			//Any WebKit
-			return true
-		}
-		if base.DetectS60OssBrowser() == true {
-			return true
-		}
-		//Note: 'High' BlackBerry devices ONLY
-		if base.DetectBlackBerryHigh() == true {
-			return true
-		}
-		//Older Windows 'Mobile' isn't good enough for iPhone Tier.
-		if base.DetectWindowsMobile() == true {
-			return true
-		}
-		if strings.Index(base.userAgentHeader, engineTelecaQ) > -1 {
-			return true
-		} else {
-			//default
-			return false
-		}
-	} else {
+func (device Device) TierRichCss() bool {
+	if device.excludedBot() {
 		return false
 	}
+	return device.is(scanTierRichCss)
 }
 
 //**************************
 // The quick way to detect for a tier of devices.
 //   This method detects for all other types of phones,
 //   but excludes the iPhone and RichCSS Tier devices.
-func (base *UAgentInfo) DetectTierOtherPhones() int {
-	if base.initCompleted == true ||
-		base.IsTierGenericMobile == true {
-		return base.IsTierGenericMobile
-	}
-
-	//Exclude devices in the other 2 categories
-	if (base.DetectMobileLong() == true) && (base.DetectTierIphone() == false) && (base.DetectTierRichCss() == false) {
-		return true
-	} else {
+func (device Device) TierOtherPhones() bool {
+	if device.excludedBot() {
 		return false
 	}
+	return device.is(scanTierOtherPhones)
 }
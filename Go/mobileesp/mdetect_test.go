@@ -0,0 +1,53 @@
+package mobileesp
+
+import "testing"
+
+var tabletUserAgents = []string{
+	"Mozilla/5.0 (iPad; CPU OS 7_0_6 like Mac OS X) AppleWebKit/537.51.1 (KHTML, like Gecko) Coast/2.0.5.71150 Mobile/11B651 Safari/7534.48.3",
+	"Mozilla/5.0 (iPad; U; CPU OS 4_3_1 like Mac OS X; en-us) AppleWebKit/533.17.9 (KHTML, like Gecko) Version/5.0.2 Mobile/8G4 Safari/6533.18.5",
+	"Mozilla/5.0 (Linux; U; Android 3.0.1; en-us; Xoom Build/HWI69) AppleWebKit/534.13 (KHTML, like Gecko) Version/4.0 Safari/534.13",
+	"Mozilla/5.0 (PlayBook; U; RIM Tablet OS 1.0.0; en-US) AppleWebKit/534.11+ (KHTML, like Gecko) Version/7.1.0.7 Safari/534.11+",
+	"Mozilla/5.0 (hp-tablet; Linux; hpwOS/3.0.2; U; en-US) AppleWebKit/534.6 (KHTML, like Gecko) wOSBrowser/234.40.1 Safari/534.6 TouchPad/1.0",
+	"Mozilla/5.0 (Tablet; rv:26.0) Gecko/26.0 Firefox/26.0",
+}
+
+func TestTabletIsNeverMobilePhone(t *testing.T) {
+	for _, userAgent := range tabletUserAgents {
+		detect := NewFromStrings(userAgent, "")
+		if !detect.Device().TierTablet() {
+			t.Errorf("TierTablet() = false, want true for %q", userAgent)
+		}
+		if detect.IsMobilePhone != 0 {
+			t.Errorf("IsMobilePhone = %d, want 0 for %q", detect.IsMobilePhone, userAgent)
+		}
+	}
+}
+
+func TestDetectionOrderDoesNotMatter(t *testing.T) {
+	for _, userAgent := range tabletUserAgents {
+		quickFirst := &UAgentInfo{}
+		quickFirst.userAgentHeader = NewFromStrings(userAgent, "").GetUserAgent()
+		if quickFirst.Device().MobileQuick() {
+			t.Errorf("MobileQuick() before TierTablet() = true, want false for %q", userAgent)
+		}
+
+		tabletFirst := &UAgentInfo{}
+		tabletFirst.userAgentHeader = quickFirst.userAgentHeader
+		tabletFirst.Device().TierTablet()
+		if tabletFirst.Device().MobileQuick() {
+			t.Errorf("MobileQuick() after TierTablet() = true, want false for %q", userAgent)
+		}
+	}
+}
+
+func TestEvaluateUsesStoredResult(t *testing.T) {
+	detect := NewFromStrings("", "")
+	if detect.Device().Iphone() {
+		t.Fatal("Iphone() = true, want false for an empty UA")
+	}
+
+	detect.results[detect.rules.builtin[scanIphone]] = scanYes
+	if !detect.Device().Iphone() {
+		t.Fatal("Iphone() rescanned instead of using the stored result")
+	}
+}
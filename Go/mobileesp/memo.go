@@ -0,0 +1,243 @@
+package mobileesp
+
+//**************************
+// Every rule is evaluated lazily, at most once per UAgentInfo.
+//   A rule that depends on another one simply evaluates it, so results
+//   are always computed in dependency order no matter which method the
+//   caller asks for first.
+
+// The built-in detections, one per Device method.
+type detection int
+
+const (
+	scanIphone detection = iota
+	scanIpod
+	scanIpad
+	scanIpadDesktopMode
+	scanIphoneOrIpod
+	scanIos
+	scanAndroid
+	scanAndroidPhone
+	scanAndroidTablet
+	scanAndroidWebKit
+	scanGoogleTV
+	scanSmartTV
+	scanAndroidTV
+	scanFireTV
+	scanRokuTV
+	scanAppleTV
+	scanChromecast
+	scanHbbTV
+	scanVidaa
+	scanWearable
+	scanAutomotive
+	scanWebkit
+	scanWindowsPhone
+	scanWindowsPhone7
+	scanWindowsPhone8
+	scanWindowsPhone10
+	scanWindowsMobile
+	scanBlackBerry
+	scanBlackBerry10Phone
+	scanBlackBerryTablet
+	scanBlackBerryWebKit
+	scanBlackBerryTouch
+	scanBlackBerryHigh
+	scanBlackBerryLow
+	scanS60OssBrowser
+	scanSymbianOS
+	scanPalmOS
+	scanPalmWebOS
+	scanWebOSTablet
+	scanWebOSTV
+	scanOperaMobile
+	scanKindle
+	scanAmazonSilk
+	scanFireOS
+	scanFireTablet
+	scanGarminNuvifone
+	scanBada
+	scanTizen
+	scanTizenTV
+	scanMeego
+	scanMeegoPhone
+	scanFirefoxOS
+	scanFirefoxOSPhone
+	scanFirefoxOSTablet
+	scanSailfish
+	scanSailfishPhone
+	scanUbuntu
+	scanUbuntuPhone
+	scanUbuntuTablet
+	scanHarmonyOS
+	scanHarmonyOSPhone
+	scanHarmonyOSTablet
+	scanKaiOS
+	scanChromeOS
+	scanFoldable
+	scanDangerHiptop
+	scanSonyMylo
+	scanMaemoTablet
+	scanArchos
+	scanGameConsole
+	scanSonyPlaystation
+	scanGamingHandheld
+	scanNintendo
+	scanXbox
+	scanBrewDevice
+	scanWapWml
+	scanMidpCapable
+	scanSmartphone
+	scanMobileQuick
+	scanMobileLong
+	scanTierTablet
+	scanTierTenFoot
+	scanTierIphone
+	scanTierRichCss
+	scanTierOtherPhones
+	scanBot
+	scanMobileBot
+
+	scanCount
+)
+
+type scanState uint8
+
+const (
+	scanPending scanState = iota
+	scanRunning
+	scanNo
+	scanYes
+)
+
+// The rule name behind each built-in Device method.
+var detectionNames = [scanCount]string{
+	scanIphone:            "iphone",
+	scanIpod:              "ipod",
+	scanIpad:              "ipad",
+	scanIpadDesktopMode:   "ipaddesktopmode",
+	scanIphoneOrIpod:      "iphoneoripod",
+	scanIos:               "ios",
+	scanAndroid:           "android",
+	scanAndroidPhone:      "androidphone",
+	scanAndroidTablet:     "androidtablet",
+	scanAndroidWebKit:     "androidwebkit",
+	scanGoogleTV:          "googletv",
+	scanSmartTV:           "smarttv",
+	scanAndroidTV:         "androidtv",
+	scanFireTV:            "firetv",
+	scanRokuTV:            "rokutv",
+	scanAppleTV:           "appletv",
+	scanChromecast:        "chromecast",
+	scanHbbTV:             "hbbtv",
+	scanVidaa:             "vidaa",
+	scanWearable:          "wearable",
+	scanAutomotive:        "automotive",
+	scanWebkit:            "webkit",
+	scanWindowsPhone:      "windowsphone",
+	scanWindowsPhone7:     "windowsphone7",
+	scanWindowsPhone8:     "windowsphone8",
+	scanWindowsPhone10:    "windowsphone10",
+	scanWindowsMobile:     "windowsmobile",
+	scanBlackBerry:        "blackberry",
+	scanBlackBerry10Phone: "blackberry10phone",
+	scanBlackBerryTablet:  "blackberrytablet",
+	scanBlackBerryWebKit:  "blackberrywebkit",
+	scanBlackBerryTouch:   "blackberrytouch",
+	scanBlackBerryHigh:    "blackberryhigh",
+	scanBlackBerryLow:     "blackberrylow",
+	scanS60OssBrowser:     "s60ossbrowser",
+	scanSymbianOS:         "symbianos",
+	scanPalmOS:            "palmos",
+	scanPalmWebOS:         "palmwebos",
+	scanWebOSTablet:       "webostablet",
+	scanWebOSTV:           "webostv",
+	scanOperaMobile:       "operamobile",
+	scanKindle:            "kindle",
+	scanAmazonSilk:        "amazonsilk",
+	scanFireOS:            "fireos",
+	scanFireTablet:        "firetablet",
+	scanGarminNuvifone:    "garminnuvifone",
+	scanBada:              "bada",
+	scanTizen:             "tizen",
+	scanTizenTV:           "tizentv",
+	scanMeego:             "meego",
+	scanMeegoPhone:        "meegophone",
+	scanFirefoxOS:         "firefoxos",
+	scanFirefoxOSPhone:    "firefoxosphone",
+	scanFirefoxOSTablet:   "firefoxostablet",
+	scanSailfish:          "sailfish",
+	scanSailfishPhone:     "sailfishphone",
+	scanUbuntu:            "ubuntu",
+	scanUbuntuPhone:       "ubuntuphone",
+	scanUbuntuTablet:      "ubuntutablet",
+	scanHarmonyOS:         "harmonyos",
+	scanHarmonyOSPhone:    "harmonyosphone",
+	scanHarmonyOSTablet:   "harmonyostablet",
+	scanKaiOS:             "kaios",
+	scanChromeOS:          "chromeos",
+	scanFoldable:          "foldable",
+	scanDangerHiptop:      "dangerhiptop",
+	scanSonyMylo:          "sonymylo",
+	scanMaemoTablet:       "maemotablet",
+	scanArchos:            "archos",
+	scanGameConsole:       "gameconsole",
+	scanSonyPlaystation:   "sonyplaystation",
+	scanGamingHandheld:    "gaminghandheld",
+	scanNintendo:          "nintendo",
+	scanXbox:              "xbox",
+	scanBrewDevice:        "brewdevice",
+	scanWapWml:            "wapwml",
+	scanMidpCapable:       "midpcapable",
+	scanSmartphone:        "smartphone",
+	scanMobileQuick:       "mobilequick",
+	scanMobileLong:        "mobilelong",
+	scanTierTablet:        "tiertablet",
+	scanTierTenFoot:       "tiertenfoot",
+	scanTierIphone:        "tieriphone",
+	scanTierRichCss:       "tierrichcss",
+	scanTierOtherPhones:   "tierotherphones",
+	scanBot:               "bot",
+	scanMobileBot:         "mobilebot",
+}
+
+//**************************
+// Returns the result of a built-in detection.
+func (device Device) is(id detection) bool {
+	return device.evaluate(device.info.rules.builtin[id])
+}
+
+//**************************
+// Returns the stored result for rule i, matching it the first time it is asked for.
+//   Client hints, when they decide the rule, are used instead of the UA.
+func (device Device) evaluate(i int) bool {
+	results := device.info.results
+	switch results[i] {
+	case scanYes:
+		return true
+	case scanNo:
+		return false
+	case scanRunning:
+		panic("mobileesp: detection depends on itself")
+	}
+
+	results[i] = scanRunning
+	rule := &device.info.rules.list[i]
+	if device.match(rule) {
+		results[i] = scanYes
+		return true
+	}
+	results[i] = scanNo
+	return false
+}
+
+//**************************
+// Evaluates every rule and scans the tokens, so later calls only read the
+//   results. Used by Cache, which shares one UAgentInfo between goroutines.
+func (base *UAgentInfo) evaluateAll() {
+	device := base.Device()
+	device.tokens()
+	for i := range base.results {
+		device.evaluate(i)
+	}
+}
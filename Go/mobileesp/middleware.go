@@ -0,0 +1,86 @@
+package mobileesp
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKey struct{}
+
+type middleware struct {
+	next         http.Handler
+	vary         bool
+	clientHints  bool
+	criticalHint bool
+	cache        *Cache
+}
+
+// MiddlewareOption configures Middleware.
+type MiddlewareOption func(*middleware)
+
+//**************************
+// Sets "Vary: User-Agent, Accept" on every response, so downstream caches
+//   keep the mobile and desktop variants of a page apart.
+func WithVary() MiddlewareOption {
+	return func(m *middleware) {
+		m.vary = true
+	}
+}
+
+//**************************
+// Asks browsers for the User-Agent Client Hints with RequestClientHints.
+func WithClientHints(critical bool) MiddlewareOption {
+	return func(m *middleware) {
+		m.clientHints = true
+		m.criticalHint = critical
+	}
+}
+
+//**************************
+// Looks detections up in cache instead of scanning every request.
+func WithCache(cache *Cache) MiddlewareOption {
+	return func(m *middleware) {
+		m.cache = cache
+	}
+}
+
+//**************************
+// Runs detection once per request and stores the *UAgentInfo in the request
+//   context. Handlers read it back with FromContext.
+func Middleware(next http.Handler, options ...MiddlewareOption) http.Handler {
+	m := &middleware{next: next}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+func (m *middleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if m.vary {
+		w.Header().Add("Vary", "User-Agent, Accept")
+	}
+	if m.clientHints {
+		RequestClientHints(w.Header(), m.criticalHint)
+	}
+	var detect *UAgentInfo
+	if m.cache != nil {
+		detect = m.cache.NewMDetect(r)
+	} else {
+		detect = NewMDetect(r)
+	}
+	ctx := NewContext(r.Context(), detect)
+	m.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+//**************************
+// Returns a copy of ctx that carries detect.
+func NewContext(ctx context.Context, detect *UAgentInfo) context.Context {
+	return context.WithValue(ctx, contextKey{}, detect)
+}
+
+//**************************
+// Returns the *UAgentInfo stored by Middleware, if any.
+func FromContext(ctx context.Context) (*UAgentInfo, bool) {
+	detect, ok := ctx.Value(contextKey{}).(*UAgentInfo)
+	return detect, ok
+}
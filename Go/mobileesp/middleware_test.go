@@ -0,0 +1,67 @@
+package mobileesp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	const iphone = "Mozilla/5.0 (iPhone; CPU iPhone OS 8_3 like Mac OS X) AppleWebKit/600.1.4 (KHTML, like Gecko) Version/8.0 Mobile/12F70 Safari/600.1.4"
+
+	for _, vary := range []bool{false, true} {
+		var options []MiddlewareOption
+		if vary {
+			options = append(options, WithVary())
+		}
+
+		called := false
+		handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			detect, ok := FromContext(r.Context())
+			if !ok {
+				t.Fatal("FromContext() found no detection")
+			}
+			if !detect.Device().TierIphone() {
+				t.Errorf("TierIphone() = false, want true")
+			}
+		}), options...)
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("User-Agent", iphone)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		if !called {
+			t.Fatal("next handler was not called")
+		}
+		if got := recorder.Header().Get("Vary") != ""; got != vary {
+			t.Errorf("Vary set = %v, want %v", got, vary)
+		}
+	}
+
+	if _, ok := FromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context()); ok {
+		t.Error("FromContext() found a detection outside the middleware")
+	}
+}
+
+func TestMiddlewareWithCache(t *testing.T) {
+	cache := NewCache(0)
+	var detections []*UAgentInfo
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		detect, _ := FromContext(r.Context())
+		detections = append(detections, detect)
+	}), WithCache(cache))
+
+	for i := 0; i < 2; i++ {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("User-Agent", benchmarkUserAgents[0])
+		handler.ServeHTTP(httptest.NewRecorder(), request)
+	}
+	if len(detections) != 2 || detections[0] != detections[1] {
+		t.Error("the second request did not reuse the cached detection")
+	}
+	if got := cache.Stats(); got.Hits != 1 || got.Misses != 1 {
+		t.Errorf("Stats() = %+v, want 1 hit and 1 miss", got)
+	}
+}
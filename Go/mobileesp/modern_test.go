@@ -0,0 +1,91 @@
+package mobileesp
+
+import "testing"
+
+func TestModernDevices(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		is        []string //Rules that match.
+		platform  Platform
+		tier      Tier
+		mobile    bool
+	}{
+		{
+			"Fire HD 10", "Mozilla/5.0 (Linux; Android 9; KFMAWI) AppleWebKit/537.36 (KHTML, like Gecko) Silk/114.2.1 like Chrome/114.0.5735.196 Safari/537.36",
+			[]string{"fireos", "firetablet"}, PlatformFireOS, TierTablet, false,
+		},
+		{
+			"Kindle Fire in Silk mobile mode", "Mozilla/5.0 (Linux; U; Android 4.0.3; en-us; KFTT Build/IML74K) AppleWebKit/535.19 (KHTML, like Gecko) Silk/2.1 Mobile Safari/535.19 Silk-Accelerated=true",
+			[]string{"fireos", "firetablet", "!androidphone"}, PlatformFireOS, TierTablet, false,
+		},
+		{
+			"HarmonyOS phone", "Mozilla/5.0 (Linux; Android 10; HarmonyOS; ELS-NX9; HMSCore 6.1.0.314) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/92.0.4515.105 HuaweiBrowser/12.0.1.300 Mobile Safari/537.36",
+			[]string{"harmonyos", "harmonyosphone", "androidphone"}, PlatformHarmonyOS, TierIphone, true,
+		},
+		{
+			"HarmonyOS NEXT phone", "Mozilla/5.0 (Phone; OpenHarmony 4.1) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36 ArkWeb/4.1.6.1 Mobile",
+			[]string{"harmonyos", "harmonyosphone", "!android"}, PlatformHarmonyOS, TierIphone, true,
+		},
+		{
+			"HarmonyOS NEXT tablet", "Mozilla/5.0 (Tablet; OpenHarmony 4.1) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36 ArkWeb/4.1.6.1",
+			[]string{"harmonyostablet"}, PlatformHarmonyOS, TierTablet, false,
+		},
+		{
+			"KaiOS", "Mozilla/5.0 (Mobile; Nokia 8110 4G; rv:48.0) Gecko/48.0 Firefox/48.0 KAIOS/2.5",
+			[]string{"kaios", "!firefoxos"}, PlatformKaiOS, TierRichCss, true,
+		},
+		{
+			"Chromebook", "Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36",
+			[]string{"chromeos"}, PlatformChromeOS, TierNone, false,
+		},
+		{
+			"Galaxy Z Fold5", "Mozilla/5.0 (Linux; Android 13; SM-F946B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36",
+			[]string{"foldable"}, PlatformAndroid, TierIphone, true,
+		},
+		{
+			"Mac Safari", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Safari/605.1.15",
+			[]string{"!ipad", "!ipaddesktopmode"}, PlatformUnknown, TierNone, false,
+		},
+		{
+			"Windows", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36",
+			[]string{"!chromeos", "!foldable", "!harmonyos", "!fireos"}, PlatformUnknown, TierNone, false,
+		},
+	}
+
+	for _, test := range tests {
+		detect := NewFromStrings(test.userAgent, "")
+		device := detect.Device()
+		for _, name := range test.is {
+			want := name[0] != '!'
+			if !want {
+				name = name[1:]
+			}
+			if got := device.Is(name); got != want {
+				t.Errorf("%s: Is(%q) = %v, want %v", test.name, name, got, want)
+			}
+		}
+		info := detect.Classify()
+		if info.Platform != test.platform || info.Tier != test.tier || info.Mobile != test.mobile {
+			t.Errorf("%s: Classify() = %+v, want platform %q tier %q mobile %v", test.name, info, test.platform, test.tier, test.mobile)
+		}
+	}
+}
+
+func TestIpadDesktopMode(t *testing.T) {
+	const userAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Safari/605.1.15"
+
+	mac := NewFromHints(userAgent, "", ClientHints{TouchPoints: 0, TouchPointsSent: true})
+	if mac.Device().Ipad() || mac.Device().TierTablet() {
+		t.Error("a Mac without touch points was detected as an iPad")
+	}
+
+	ipad := NewFromHints(userAgent, "", ClientHints{TouchPoints: 5, TouchPointsSent: true})
+	device := ipad.Device()
+	if !device.IpadDesktopMode() || !device.Ipad() || !device.Ios() || !device.TierTablet() || device.MobileQuick() {
+		t.Errorf("an iPad in desktop mode was not detected: %+v", ipad.Classify())
+	}
+	if got := ipad.OSVersion(); got != (Version{16, 5, 0}) {
+		t.Errorf("OSVersion() = %v, want 16.5.0", got)
+	}
+}
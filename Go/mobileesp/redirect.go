@@ -0,0 +1,150 @@
+package mobileesp
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Default names of the "view full site" query parameter and cookie.
+const (
+	DefaultFullSiteParam  = "fullsite"
+	DefaultFullSiteCookie = "mobileesp_fullsite"
+)
+
+// RedirectHandler sends devices to a per-tier site, such as phones to m.example.com,
+// keeping the path and query. Requests that are not redirected go to Next.
+//
+// Visitors opt out with ?fullsite=1, which also sets a cookie so later
+// requests stay on the full site. ?fullsite=0 clears the cookie.
+type RedirectHandler struct {
+	Next http.Handler
+
+	TabletURL string //Target for DetectTierTablet(). Empty keeps tablets on Next.
+	IphoneURL string //Target for DetectTierIphone(). Empty sends them to MobileURL.
+	MobileURL string //Target for any other DetectMobileQuick() device.
+
+	FullSiteParam  string //Defaults to DefaultFullSiteParam.
+	FullSiteCookie string //Defaults to DefaultFullSiteCookie.
+	Code           int    //Defaults to http.StatusFound.
+}
+
+func (handler *RedirectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "User-Agent, Accept, Cookie")
+
+	if !handler.fullSite(w, r) {
+		if target := handler.target(r); target != "" {
+			if location, ok := redirectLocation(target, r, handler.param()); ok {
+				code := handler.Code
+				if code == 0 {
+					code = http.StatusFound
+				}
+				http.Redirect(w, r, location, code)
+				return
+			}
+		}
+	}
+	handler.Next.ServeHTTP(w, r)
+}
+
+func (handler *RedirectHandler) param() string {
+	if handler.FullSiteParam != "" {
+		return handler.FullSiteParam
+	}
+	return DefaultFullSiteParam
+}
+
+func (handler *RedirectHandler) cookie() string {
+	if handler.FullSiteCookie != "" {
+		return handler.FullSiteCookie
+	}
+	return DefaultFullSiteCookie
+}
+
+//**************************
+// Reports whether the visitor asked for the full site, remembering
+//   the choice in a cookie when it comes from the query.
+func (handler *RedirectHandler) fullSite(w http.ResponseWriter, r *http.Request) bool {
+	if values, ok := r.URL.Query()[handler.param()]; ok {
+		if parseOverride(values[0]) {
+			http.SetCookie(w, &http.Cookie{Name: handler.cookie(), Value: "1", Path: "/", HttpOnly: true})
+			return true
+		}
+		http.SetCookie(w, &http.Cookie{Name: handler.cookie(), Value: "", Path: "/", MaxAge: -1, HttpOnly: true})
+		return false
+	}
+
+	cookie, err := r.Cookie(handler.cookie())
+	return err == nil && parseOverride(cookie.Value)
+}
+
+func parseOverride(value string) bool {
+	switch strings.ToLower(value) {
+	case "", "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+//**************************
+// Picks the target URL for the device's tier. Tablets are tested first,
+//   since DetectMobileQuick() excludes them. iPhone-tier devices go to
+//   MobileURL when IphoneURL is empty.
+func (handler *RedirectHandler) target(r *http.Request) string {
+	detect, ok := FromContext(r.Context())
+	if !ok {
+		detect = NewMDetect(r)
+	}
+
+	device := detect.Device()
+	switch {
+	case device.TierTablet():
+		return handler.TabletURL
+	case device.TierIphone() && handler.IphoneURL != "":
+		return handler.IphoneURL
+	case device.MobileQuick():
+		return handler.MobileURL
+	}
+	return ""
+}
+
+//**************************
+// Joins target with the request path and query, dropping the override parameter.
+//   Returns false when the request is already on the target site.
+func redirectLocation(target string, r *http.Request, param string) (string, bool) {
+	location, err := url.Parse(target)
+	if err != nil {
+		return "", false
+	}
+
+	prefix := strings.TrimSuffix(location.Path, "/")
+	if location.Host == "" || strings.EqualFold(location.Host, r.Host) {
+		if prefix == "" || r.URL.Path == prefix || strings.HasPrefix(r.URL.Path, prefix+"/") {
+			return "", false
+		}
+	}
+
+	location.Path = prefix + r.URL.Path
+	location.RawPath = ""
+	location.RawQuery = removeQueryParam(r.URL.RawQuery, param)
+	return location.String(), true
+}
+
+//**************************
+// Drops every param pair from rawQuery and keeps the others as sent,
+//   in order and with their escaping, so signed URLs still verify.
+func removeQueryParam(rawQuery string, param string) string {
+	pairs := strings.Split(rawQuery, "&")
+	kept := pairs[:0]
+	for _, pair := range pairs {
+		key := pair
+		if i := strings.IndexByte(pair, '='); i >= 0 {
+			key = pair[:i]
+		}
+		if unescaped, err := url.QueryUnescape(key); err == nil && unescaped == param {
+			continue
+		}
+		kept = append(kept, pair)
+	}
+	return strings.Join(kept, "&")
+}
@@ -0,0 +1,108 @@
+package mobileesp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRedirectHandler(t *testing.T) {
+	const (
+		iphone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 8_3 like Mac OS X) AppleWebKit/600.1.4 (KHTML, like Gecko) Version/8.0 Mobile/12F70 Safari/600.1.4"
+		ipad    = "Mozilla/5.0 (iPad; CPU OS 7_0_6 like Mac OS X) AppleWebKit/537.51.1 (KHTML, like Gecko) Coast/2.0.5.71150 Mobile/11B651 Safari/7534.48.3"
+		feature = "MOT-V9mm/00.62 UP.Browser/6.2.3.4.c.1.123 (GUI) MMP/2.0"
+		desktop = "Mozilla/5.0 (Windows NT 6.1; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/42.0.2311.135 Safari/537.36"
+	)
+
+	handler := &RedirectHandler{
+		Next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+		IphoneURL: "https://m.example.com",
+		MobileURL: "https://wap.example.com/site",
+	}
+
+	tests := []struct {
+		userAgent string
+		target    string
+		cookie    string
+		code      int
+		location  string
+	}{
+		{iphone, "/news?id=7", "", http.StatusFound, "https://m.example.com/news?id=7"},
+		{feature, "/news?id=7", "", http.StatusFound, "https://wap.example.com/site/news?id=7"},
+		{ipad, "/news?id=7", "", http.StatusOK, ""},
+		{desktop, "/news?id=7", "", http.StatusOK, ""},
+		{iphone, "/news?id=7&fullsite=1", "", http.StatusOK, ""},
+		{iphone, "/news?id=7", "1", http.StatusOK, ""},
+		{iphone, "/news?id=7&fullsite=0", "1", http.StatusFound, "https://m.example.com/news?id=7"},
+		//The rest of the query is kept as sent, for signed URLs.
+		{iphone, "/news?z=1&a=%7e&sig=x%2Fy", "", http.StatusFound, "https://m.example.com/news?z=1&a=%7e&sig=x%2Fy"},
+		{iphone, "/news?z=1&fullsite=0&a=%7e", "", http.StatusFound, "https://m.example.com/news?z=1&a=%7e"},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, "http://www.example.com"+test.target, nil)
+		request.Header.Set("User-Agent", test.userAgent)
+		if test.cookie != "" {
+			request.AddCookie(&http.Cookie{Name: DefaultFullSiteCookie, Value: test.cookie})
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		if recorder.Code != test.code {
+			t.Errorf("%s %s: code = %d, want %d", test.userAgent, test.target, recorder.Code, test.code)
+		}
+		if got := recorder.Header().Get("Location"); got != test.location {
+			t.Errorf("%s %s: Location = %q, want %q", test.userAgent, test.target, got, test.location)
+		}
+	}
+}
+
+func TestRedirectHandlerSetsFullSiteCookie(t *testing.T) {
+	handler := &RedirectHandler{
+		Next:      http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		IphoneURL: "https://m.example.com",
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/?fullsite=1", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	cookies := recorder.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != DefaultFullSiteCookie || cookies[0].Value != "1" {
+		t.Errorf("cookies = %v, want %s=1", cookies, DefaultFullSiteCookie)
+	}
+}
+
+func TestRedirectHandlerStaysOnTarget(t *testing.T) {
+	handler := &RedirectHandler{
+		Next:      http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		IphoneURL: "/m",
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/m/news", nil)
+	request.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 8_3 like Mac OS X) AppleWebKit/600.1.4 (KHTML, like Gecko) Version/8.0 Mobile/12F70 Safari/600.1.4")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("code = %d, want %d", recorder.Code, http.StatusOK)
+	}
+}
+
+func TestRedirectHandlerIphoneFallsBackToMobile(t *testing.T) {
+	handler := &RedirectHandler{
+		Next:      http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		MobileURL: "https://m.example.com",
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "http://www.example.com/news", nil)
+	request.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 8_3 like Mac OS X) AppleWebKit/600.1.4 (KHTML, like Gecko) Version/8.0 Mobile/12F70 Safari/600.1.4")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusFound || recorder.Header().Get("Location") != "https://m.example.com/news" {
+		t.Errorf("code = %d, Location = %q, want %d to https://m.example.com/news", recorder.Code, recorder.Header().Get("Location"), http.StatusFound)
+	}
+}
@@ -0,0 +1,142 @@
+package mobileesp
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+)
+
+// The variants tried for each tier by default, in order. A request whose
+// variants are all missing gets the base template, such as page.html.
+var DefaultTemplateFallbacks = map[Tier][]string{
+	TierTablet:  {"tablet"},
+	TierTenFoot: {"tenfoot"},
+	TierIphone:  {"iphone", "richcss", "generic"},
+	TierRichCss: {"richcss", "generic"},
+	TierOther:   {"generic"},
+}
+
+// TemplateResolver serves the markup variant of a page that suits the tier
+// of the request. For the base name "page.html" and a TierIphone request it
+// tries page.iphone.html, page.richcss.html and page.generic.html, then falls
+// back to page.html. Templates are parsed once, with TemplateFuncs, and
+// executed with the functions bound to the request.
+//
+// Responses differ by User Agent, so wrap the handler in Middleware with WithVary.
+type TemplateResolver struct {
+	FS fs.FS //Holds the templates.
+
+	Fallbacks map[Tier][]string //The variants tried for each tier. Defaults to DefaultTemplateFallbacks.
+	Shared    []string          //Glob patterns of layouts and partials parsed with every page, such as "partials/*.html".
+	Funcs     template.FuncMap  //Added to the TemplateFuncs functions.
+
+	mutex    sync.Mutex
+	parsed   map[string]*template.Template //By file name.
+	resolved map[resolvedKey]string        //The file chosen for a tier and base name.
+}
+
+type resolvedKey struct {
+	tier Tier
+	name string
+}
+
+//**************************
+// Returns the file name of the variant of name for tier, following the
+//   fallback chain down to name itself.
+func (resolver *TemplateResolver) Lookup(tier Tier, name string) (string, error) {
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+	return resolver.lookup(tier, name)
+}
+
+func (resolver *TemplateResolver) lookup(tier Tier, name string) (string, error) {
+	key := resolvedKey{tier, name}
+	if file, ok := resolver.resolved[key]; ok {
+		return file, nil
+	}
+
+	fallbacks := resolver.Fallbacks
+	if fallbacks == nil {
+		fallbacks = DefaultTemplateFallbacks
+	}
+	extension := path.Ext(name)
+	candidates := append(append([]string(nil), fallbacks[tier]...), "")
+	for _, candidate := range candidates {
+		file := name
+		if candidate != "" {
+			file = strings.TrimSuffix(name, extension) + "." + candidate + extension
+		}
+		if _, err := fs.Stat(resolver.FS, file); err == nil {
+			if resolver.resolved == nil {
+				resolver.resolved = map[resolvedKey]string{}
+			}
+			resolver.resolved[key] = file
+			return file, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	return "", &fs.PathError{Op: "resolve", Path: name, Err: fs.ErrNotExist}
+}
+
+//**************************
+// Returns the parsed variant of name for the tier of r, with the
+//   TemplateFuncs functions bound to r. The detection stored by
+//   Middleware is used when present.
+func (resolver *TemplateResolver) Resolve(r *http.Request, name string) (*template.Template, error) {
+	detect, ok := FromContext(r.Context())
+	if !ok {
+		detect = NewMDetect(r)
+		r = r.WithContext(NewContext(r.Context(), detect))
+	}
+
+	resolver.mutex.Lock()
+	file, err := resolver.lookup(detect.Device().tier(), name)
+	var tmpl *template.Template
+	if err == nil {
+		tmpl, err = resolver.parse(file)
+	}
+	resolver.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	clone, err := tmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return clone.Funcs(TemplateFuncs(r.Context())), nil
+}
+
+//**************************
+// Executes the variant of name for the tier of r with data.
+func (resolver *TemplateResolver) Execute(w io.Writer, r *http.Request, name string, data interface{}) error {
+	tmpl, err := resolver.Resolve(r, name)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, data)
+}
+
+func (resolver *TemplateResolver) parse(file string) (*template.Template, error) {
+	if tmpl, ok := resolver.parsed[file]; ok {
+		return tmpl, nil
+	}
+
+	tmpl := template.New(path.Base(file)).Funcs(TemplateFuncs(context.Background())).Funcs(resolver.Funcs)
+	tmpl, err := tmpl.ParseFS(resolver.FS, append([]string{file}, resolver.Shared...)...)
+	if err != nil {
+		return nil, err
+	}
+	if resolver.parsed == nil {
+		resolver.parsed = map[string]*template.Template{}
+	}
+	resolver.parsed[file] = tmpl
+	return tmpl, nil
+}
@@ -0,0 +1,83 @@
+package mobileesp
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTemplateResolver(t *testing.T) {
+	files := fstest.MapFS{
+		"page.html":             {Data: []byte(`{{define "page.html"}}desktop {{template "footer"}}{{end}}`)},
+		"page.tablet.html":      {Data: []byte(`tablet {{template "footer"}}`)},
+		"page.richcss.html":     {Data: []byte(`richcss {{platform}} {{.}} {{template "footer"}}`)},
+		"page.generic.html":     {Data: []byte(`generic {{template "footer"}}`)},
+		"partials/footer.html":  {Data: []byte(`{{define "footer"}}footer{{end}}`)},
+		"other/index.html":      {Data: []byte(`index`)},
+		"other/index.ios.html":  {Data: []byte(`ios`)},
+		"other/index.tablet.md": {Data: []byte(`wrong extension`)},
+	}
+	resolver := &TemplateResolver{FS: files, Shared: []string{"partials/*.html"}}
+
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"Mozilla/5.0 (iPad; U; CPU OS 4_3_1 like Mac OS X; en-us) AppleWebKit/533.17.9 (KHTML, like Gecko) Version/5.0.2 Mobile/8G4 Safari/6533.18.5", "tablet footer"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Mobile/15E148 Safari/604.1", "richcss ios data footer"},
+		{"Mozilla/5.0 (BlackBerry; U; BlackBerry 9800; en-US) AppleWebKit/534.1+ (KHTML, like Gecko) Version/6.0.0.246 Mobile Safari/534.1+", "richcss blackberry data footer"},
+		{"Opera/9.80 (J2ME/MIDP; Opera Mini/4.2.13221/25.623; U; en) Presto/2.5.25 Version/10.54", "generic footer"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36", "desktop footer"},
+		{"Roku/DVP-5.2 (025.02E03197A)", "desktop footer"},
+	}
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("User-Agent", test.userAgent)
+		var out strings.Builder
+		if err := resolver.Execute(&out, request, "page.html", "data"); err != nil {
+			t.Errorf("Execute() = %v\nUA: %s", err, test.userAgent)
+			continue
+		}
+		if got := out.String(); got != test.want {
+			t.Errorf("Execute() wrote %q, want %q\nUA: %s", got, test.want, test.userAgent)
+		}
+	}
+
+	custom := &TemplateResolver{FS: files, Fallbacks: map[Tier][]string{TierTablet: {"ios"}, TierIphone: {"ios"}}}
+	for tier, want := range map[Tier]string{TierTablet: "other/index.ios.html", TierIphone: "other/index.ios.html", TierRichCss: "other/index.html"} {
+		if got, err := custom.Lookup(tier, "other/index.html"); got != want || err != nil {
+			t.Errorf("Lookup(%q) = %q, %v, want %q", tier, got, err, want)
+		}
+	}
+
+	if _, err := resolver.Lookup(TierIphone, "missing.html"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Lookup() = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestTemplateResolverUsesMiddleware(t *testing.T) {
+	files := fstest.MapFS{
+		"page.html":        {Data: []byte(`desktop`)},
+		"page.tablet.html": {Data: []byte(`tablet {{isTablet}}`)},
+	}
+	resolver := &TemplateResolver{FS: files}
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := resolver.Execute(w, r, "page.html", nil); err != nil {
+			t.Fatal(err)
+		}
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("User-Agent", "Mozilla/5.0 (Linux; U; Android 4.0.3; en-us; KFTT Build/IML74K) AppleWebKit/535.19 (KHTML, like Gecko) Silk/2.1 Mobile Safari/535.19 Silk-Accelerated=true")
+	for i := 0; i < 2; i++ {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if got := recorder.Body.String(); got != "tablet true" {
+			t.Errorf("response %d = %q, want %q", i, got, "tablet true")
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package mobileesp
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Rule describes one detection as data. A rule matches when:
+//   - none of ExcludeRules match and the UA contains none of Exclude,
+//   - all of Requires match and the UA contains all of UserAgentAll,
+//   - and, if it has any of them, at least one of UserAgent, Accept,
+//     Rules or its category members matches.
+//
+// A rule listing a category in Categories becomes one of that category's
+// members, so RegisterRules can extend the built-in "tiertablet" or
+// "tieriphone" rules without replacing them.
+type Rule struct {
+	Name string `json:"name" yaml:"name"`
+
+	UserAgent    []string `json:"userAgent,omitempty" yaml:"userAgent,omitempty"`       //The UA contains any of these tokens.
+	UserAgentAll []string `json:"userAgentAll,omitempty" yaml:"userAgentAll,omitempty"` //The UA contains all of these tokens.
+	Accept       []string `json:"accept,omitempty" yaml:"accept,omitempty"`             //The HTTP Accept value contains any of these tokens.
+	Exclude      []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`           //The UA contains none of these tokens.
+
+	Rules        []string `json:"rules,omitempty" yaml:"rules,omitempty"`               //Any of these rules match.
+	Requires     []string `json:"requires,omitempty" yaml:"requires,omitempty"`         //All of these rules match.
+	ExcludeRules []string `json:"excludeRules,omitempty" yaml:"excludeRules,omitempty"` //None of these rules match.
+
+	Categories []string `json:"categories,omitempty" yaml:"categories,omitempty"` //The category rules this rule is a member of.
+}
+
+// RuleError reports which rule and field of a ruleset is invalid.
+// Field uses the names of the rules file format, such as "userAgent".
+type RuleError struct {
+	Rule  string
+	Field string
+	Err   string
+}
+
+func (e *RuleError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("mobileesp: rule %q: %s", e.Rule, e.Err)
+	}
+	return fmt.Sprintf("mobileesp: rule %q: %s: %s", e.Rule, e.Field, e.Err)
+}
+
+type compiledRule struct {
+	name string
+
+	userAgent    []int //Token ids of the userAgent matcher.
+	userAgentAll []int
+	accept       []int //Token ids of the accept matcher.
+	exclude      []int
+
+	rules        []int //Rules plus the category members.
+	requires     []int
+	excludeRules []int
+}
+
+type ruleset struct {
+	source    []Rule
+	list      []compiledRule
+	index     map[string]int
+	builtin   [scanCount]int
+	userAgent *tokenMatcher //Every token the rules look for in the User Agent.
+	accept    *tokenMatcher //Every token the rules look for in the HTTP Accept value.
+}
+
+var (
+	rulesMutex  sync.RWMutex
+	activeRules = mustCompileRules(defaultRules())
+)
+
+func mustCompileRules(rules []Rule) *ruleset {
+	compiled, err := compileRules(rules)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
+
+//**************************
+// Returns a copy of the built-in rules, one for each Device method. They
+//   start from the 2015.05.13 detection methods, with newer platforms, TVs,
+//   wearables and cars added, and the last three kept out of the phone tiers.
+func DefaultRules() []Rule {
+	return copyRules(defaultRules())
+}
+
+//**************************
+// Returns a copy of the rules used by new detections.
+func ActiveRules() []Rule {
+	rulesMutex.RLock()
+	defer rulesMutex.RUnlock()
+	return copyRules(activeRules.source)
+}
+
+//**************************
+// Adds rules to the active ruleset. A rule with the name of an existing
+//   rule replaces it; names match case-insensitively. Naming the same rule
+//   twice in one call is an error. Meant to be called at startup: detections
+//   created before the call keep the rules they started with.
+func RegisterRules(rules ...Rule) error {
+	rulesMutex.Lock()
+	defer rulesMutex.Unlock()
+
+	merged := copyRules(activeRules.source)
+	positions := map[string]int{}
+	for i, rule := range merged {
+		positions[ruleName(rule.Name)] = i
+	}
+	registered := map[string]bool{}
+	for _, rule := range rules {
+		name := ruleName(rule.Name)
+		if name != "" && registered[name] {
+			return &RuleError{Rule: name, Field: "name", Err: "is defined twice"}
+		}
+		registered[name] = true
+
+		if i, ok := positions[name]; ok && name != "" {
+			merged[i] = rule
+			continue
+		}
+		positions[name] = len(merged)
+		merged = append(merged, rule)
+	}
+
+	compiled, err := compileRules(merged)
+	if err != nil {
+		return err
+	}
+	activeRules = compiled
+	return nil
+}
+
+//**************************
+// Replaces the active ruleset with the built-in rules.
+func ResetRules() {
+	rulesMutex.Lock()
+	defer rulesMutex.Unlock()
+	activeRules = mustCompileRules(defaultRules())
+}
+
+func currentRules() *ruleset {
+	rulesMutex.RLock()
+	defer rulesMutex.RUnlock()
+	return activeRules
+}
+
+func copyRules(rules []Rule) []Rule {
+	copied := make([]Rule, len(rules))
+	for i, rule := range rules {
+		copied[i] = Rule{
+			Name:         rule.Name,
+			UserAgent:    append([]string(nil), rule.UserAgent...),
+			UserAgentAll: append([]string(nil), rule.UserAgentAll...),
+			Accept:       append([]string(nil), rule.Accept...),
+			Exclude:      append([]string(nil), rule.Exclude...),
+			Rules:        append([]string(nil), rule.Rules...),
+			Requires:     append([]string(nil), rule.Requires...),
+			ExcludeRules: append([]string(nil), rule.ExcludeRules...),
+			Categories:   append([]string(nil), rule.Categories...),
+		}
+	}
+	return copied
+}
+
+// Rule names match case-insensitively and ignore surrounding spaces.
+func ruleName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+//**************************
+// Resolves rule names to indexes and checks that every built-in detection
+//   is defined and that no rule depends on itself.
+func compileRules(rules []Rule) (*ruleset, error) {
+	compiled := &ruleset{
+		source:    copyRules(rules),
+		list:      make([]compiledRule, len(rules)),
+		index:     make(map[string]int, len(rules)),
+		userAgent: newTokenMatcher(),
+		accept:    newTokenMatcher(),
+	}
+
+	for i, rule := range rules {
+		name := ruleName(rule.Name)
+		if name == "" {
+			return nil, &RuleError{Rule: fmt.Sprintf("#%d", i), Field: "name", Err: "is empty"}
+		}
+		if _, ok := compiled.index[name]; ok {
+			return nil, &RuleError{Rule: name, Field: "name", Err: "is defined twice"}
+		}
+		compiled.index[name] = i
+		compiled.list[i].name = name
+	}
+
+	for i, rule := range rules {
+		target := &compiled.list[i]
+		fields := []struct {
+			field   string
+			tokens  []string
+			matcher *tokenMatcher
+			into    *[]int
+		}{
+			{"userAgent", rule.UserAgent, compiled.userAgent, &target.userAgent},
+			{"userAgentAll", rule.UserAgentAll, compiled.userAgent, &target.userAgentAll},
+			{"accept", rule.Accept, compiled.accept, &target.accept},
+			{"exclude", rule.Exclude, compiled.userAgent, &target.exclude},
+		}
+		for _, f := range fields {
+			for _, token := range f.tokens {
+				token = strings.ToLower(token)
+				if token == "" {
+					return nil, &RuleError{Rule: target.name, Field: f.field, Err: "has an empty token"}
+				}
+				*f.into = append(*f.into, f.matcher.add(token))
+			}
+		}
+
+		references := []struct {
+			field string
+			names []string
+			into  *[]int
+		}{
+			{"rules", rule.Rules, &target.rules},
+			{"requires", rule.Requires, &target.requires},
+			{"excludeRules", rule.ExcludeRules, &target.excludeRules},
+		}
+		for _, r := range references {
+			for _, name := range r.names {
+				j, ok := compiled.index[ruleName(name)]
+				if !ok {
+					return nil, &RuleError{Rule: target.name, Field: r.field, Err: fmt.Sprintf("unknown rule %q", name)}
+				}
+				*r.into = append(*r.into, j)
+			}
+		}
+
+		for _, category := range rule.Categories {
+			j, ok := compiled.index[ruleName(category)]
+			if !ok {
+				return nil, &RuleError{Rule: target.name, Field: "categories", Err: fmt.Sprintf("unknown rule %q", category)}
+			}
+			compiled.list[j].rules = append(compiled.list[j].rules, i)
+		}
+	}
+
+	for id, name := range detectionNames {
+		i, ok := compiled.index[name]
+		if !ok {
+			return nil, &RuleError{Rule: name, Err: "built-in rule is missing"}
+		}
+		compiled.builtin[id] = i
+	}
+
+	if err := compiled.checkCycles(); err != nil {
+		return nil, err
+	}
+	compiled.userAgent.build()
+	compiled.accept.build()
+	return compiled, nil
+}
+
+func (compiled *ruleset) checkCycles() error {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make([]int, len(compiled.list))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visiting:
+			return &RuleError{Rule: compiled.list[i].name, Err: "depends on itself"}
+		case done:
+			return nil
+		}
+		state[i] = visiting
+		rule := &compiled.list[i]
+		for _, deps := range [][]int{rule.rules, rule.requires, rule.excludeRules} {
+			for _, j := range deps {
+				if err := visit(j); err != nil {
+					return err
+				}
+			}
+		}
+		state[i] = done
+		return nil
+	}
+
+	for i := range compiled.list {
+		if err := visit(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//**************************
+// Reports whether the UA matches rule, evaluating the rules it depends on.
+//   When the client hints decide the rule, they replace its UA and Accept
+//   token tests. ExcludeRules and Requires still apply.
+func (device Device) match(rule *compiledRule) bool {
+	userAgent, accept := device.tokens()
+	hintedResult, hinted := device.hinted(rule.name)
+	for _, i := range rule.excludeRules {
+		if device.evaluate(i) {
+			return false
+		}
+	}
+	if !hinted {
+		for _, token := range rule.exclude {
+			if userAgent.has(token) {
+				return false
+			}
+		}
+	}
+	for _, i := range rule.requires {
+		if !device.evaluate(i) {
+			return false
+		}
+	}
+	if hinted {
+		return hintedResult
+	}
+	for _, token := range rule.userAgentAll {
+		if !userAgent.has(token) {
+			return false
+		}
+	}
+
+	if len(rule.userAgent) == 0 && len(rule.accept) == 0 && len(rule.rules) == 0 {
+		return true
+	}
+	for _, token := range rule.userAgent {
+		if userAgent.has(token) {
+			return true
+		}
+	}
+	for _, token := range rule.accept {
+		if accept.has(token) {
+			return true
+		}
+	}
+	for _, i := range rule.rules {
+		if device.evaluate(i) {
+			return true
+		}
+	}
+	return false
+}
+
+//**************************
+// Returns the tokens found in the User Agent and HTTP Accept values.
+//   Both are scanned once, the first time a rule needs them.
+func (device Device) tokens() (bitset, bitset) {
+	info := device.info
+	if info.userAgentTokens == nil {
+		words := info.rules.userAgent.words()
+		set := make(bitset, words+info.rules.accept.words())
+		info.userAgentTokens, info.acceptTokens = set[:words:words], set[words:]
+		info.rules.userAgent.scanInto(info.userAgentHeader, info.userAgentTokens)
+		info.rules.accept.scanInto(info.httpAcceptHeader, info.acceptTokens)
+	}
+	return info.userAgentTokens, info.acceptTokens
+}
+
+//**************************
+// Reports whether the rule called name matches. Unknown names report false.
+//   Use it for rules added with RegisterRules.
+func (device Device) Is(name string) bool {
+	i, ok := device.info.rules.index[ruleName(name)]
+	if !ok {
+		return false
+	}
+	return device.evaluate(i)
+}
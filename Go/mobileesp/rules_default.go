@@ -0,0 +1,221 @@
+package mobileesp
+
+//**************************
+// The built-in rules. Each backs one Device method and is named after it
+//   in lower case. They start from the 2015.05.13 release: Fire OS,
+//   HarmonyOS, KaiOS and the other newer platforms were added to the tiers,
+//   and TVs, wearables and cars are kept out of the phone tiers. A few
+//   helper rules hold the conjunctions that a single rule can't express.
+func defaultRules() []Rule {
+	return []Rule{
+		//*****************************
+		// Start device detection
+		//*****************************
+
+		//The iPad and iPod Touch say they're an iPhone. So let's disambiguate.
+		{Name: "iphone", UserAgent: []string{deviceIphone}, ExcludeRules: []string{"ipad", "ipod"}},
+		{Name: "ipod", UserAgent: []string{deviceIpod}},
+		{Name: "ipad", UserAgent: []string{deviceIpad}, Rules: []string{"ipaddesktopmode"}, Requires: []string{"webkit"}},
+		//iPadOS 13+ Safari sends the Mac UA. Only a touch screen tells them apart.
+		{Name: "ipaddesktopmode", UserAgentAll: []string{deviceMacintosh, engineVersion, engineSafari}, Requires: []string{"touchscreen"}},
+		//Answered by the TouchPoints client hint when it is known.
+		{Name: "touchscreen", UserAgent: []string{deviceTouch}},
+		//Some iPods may report themselves as an iPhone, which would be okay.
+		{Name: "iphoneoripod", Rules: []string{"iphone", "ipod"}},
+		{Name: "ios", Rules: []string{"iphoneoripod", "ipad"}},
+
+		{Name: "android", UserAgent: []string{deviceAndroid}, Rules: []string{"googletv"}},
+		//If it's Android and has 'mobile' in it, Google says it's a phone.
+		//Android devices with Opera Mobile/Mini should report here.
+		//Fire tablets send 'mobile' too.
+		{
+			Name:         "androidphone",
+			Requires:     []string{"android"},
+			ExcludeRules: []string{"firetablet", "smarttv", "wearable", "automotive"},
+			UserAgent:    []string{mobile},
+			Rules:        []string{"operamobile"},
+		},
+		//If it's Android and does NOT have 'mobile' in it, Google says it's a tablet.
+		//TVs and cars don't say 'mobile' either.
+		{
+			Name:         "androidtablet",
+			Requires:     []string{"android"},
+			ExcludeRules: []string{"operamobile", "smarttv", "wearable", "automotive"},
+			Exclude:      []string{mobile},
+		},
+		{Name: "androidwebkit", Requires: []string{"android", "webkit"}},
+		{Name: "googletv", UserAgent: []string{deviceGoogleTV}},
+
+		//Anything used from the couch, including home consoles but not handhelds.
+		{
+			Name:         "smarttv",
+			ExcludeRules: []string{"gaminghandheld"},
+			Exclude:      handheldConsoleTokens,
+			Rules: []string{"googletv", "tizentv", "webostv", "androidtv", "firetv", "rokutv", "appletv",
+				"chromecast", "hbbtv", "vidaa", "gameconsole"},
+			UserAgent: smartTVTokens,
+		},
+		{Name: "androidtv", UserAgent: androidTVTokens},
+		{Name: "firetv", Requires: []string{"android"}, UserAgent: []string{deviceFireTV}},
+		{Name: "rokutv", UserAgent: []string{deviceRoku}},
+		{Name: "appletv", UserAgent: appleTVTokens},
+		{Name: "chromecast", UserAgent: []string{deviceChromecast}},
+		{Name: "hbbtv", UserAgent: []string{deviceHbbTV}},
+		{Name: "vidaa", UserAgent: []string{deviceVidaa}},
+
+		{Name: "wearable", UserAgent: wearableTokens},
+		{Name: "automotive", UserAgent: automotiveTokens},
+		{Name: "webkit", UserAgent: []string{engineWebKit}},
+
+		{Name: "windowsphone", Rules: []string{"windowsphone7", "windowsphone8", "windowsphone10"}},
+		{Name: "windowsphone7", UserAgent: []string{deviceWinPhone7}},
+		{Name: "windowsphone8", UserAgent: []string{deviceWinPhone8}},
+		{Name: "windowsphone10", UserAgent: []string{deviceWinPhone10}},
+		//Most devices use 'Windows CE', but some report 'iemobile'
+		//  and some older ones report as 'PIE' for Pocket IE.
+		{
+			Name:         "windowsmobile",
+			ExcludeRules: []string{"windowsphone"},
+			UserAgent:    []string{deviceWinMob, deviceIeMob, enginePie},
+			Rules:        []string{"windowsmobileppc", "windowsmobilehtc", "windowsmobilewapwml"},
+		},
+		//Test for Windows Mobile PPC but not old Macintosh PowerPC.
+		{Name: "windowsmobileppc", UserAgent: []string{devicePpc}, Exclude: []string{deviceMacPpc}},
+		//Test for certain Windows Mobile-based HTC devices.
+		{Name: "windowsmobilehtc", UserAgentAll: []string{manuHtc, deviceWindows}},
+		{Name: "windowsmobilewapwml", Requires: []string{"wapwml"}, UserAgentAll: []string{deviceWindows}},
+
+		{Name: "blackberry", UserAgent: []string{deviceBB}, Accept: []string{vndRIM}, Rules: []string{"blackberry10phone"}},
+		{Name: "blackberry10phone", UserAgentAll: []string{deviceBB10, mobile}},
+		{Name: "blackberrytablet", UserAgent: []string{deviceBBPlaybook}},
+		{Name: "blackberrywebkit", Requires: []string{"blackberry", "webkit"}},
+		{Name: "blackberrytouch", UserAgent: []string{deviceBBStorm, deviceBBTorch, deviceBBBoldTouch, deviceBBCurveTouch}},
+		//Disambiguate for BlackBerry OS 6 or 7 (WebKit) browser
+		{
+			Name:         "blackberryhigh",
+			ExcludeRules: []string{"blackberrywebkit"},
+			Requires:     []string{"blackberry"},
+			UserAgent:    []string{deviceBBBold, deviceBBTour, deviceBBCurve},
+			Rules:        []string{"blackberrytouch"},
+		},
+		//Assume that if it's not in the High tier, then it's Low.
+		{Name: "blackberrylow", Requires: []string{"blackberry"}, ExcludeRules: []string{"blackberryhigh", "blackberrywebkit"}},
+
+		{Name: "s60ossbrowser", Requires: []string{"webkit"}, UserAgent: []string{deviceSymbian, deviceS60}},
+		{Name: "symbianos", UserAgent: []string{deviceSymbian, deviceS60, deviceS70, deviceS80, deviceS90}},
+
+		//Most devices nowadays report as 'Palm', but some older ones reported as Blazer or Xiino.
+		{Name: "palmos", UserAgent: []string{devicePalm, engineBlazer, engineXiino}, ExcludeRules: []string{"palmwebos"}},
+		{Name: "palmwebos", UserAgent: []string{deviceWebOS}},
+		{Name: "webostablet", UserAgentAll: []string{deviceWebOShp, deviceTablet}},
+		{Name: "webostv", UserAgentAll: []string{deviceWebOStv, smartTV2}},
+
+		{Name: "operamobile", UserAgentAll: []string{engineOpera}, UserAgent: []string{mini, mobi}},
+		//For the Kindle Fire, use the normal Android methods.
+		{Name: "kindle", UserAgent: []string{deviceKindle}, ExcludeRules: []string{"android"}},
+		{Name: "amazonsilk", UserAgent: []string{engineSilk}},
+		{Name: "fireos", Requires: []string{"android"}, UserAgent: []string{deviceFireTablet, engineSilkFire}, Rules: []string{"firetv"}},
+		{Name: "firetablet", Requires: []string{"fireos"}, UserAgent: []string{deviceFireTablet}},
+		{Name: "garminnuvifone", UserAgent: []string{deviceNuvifone}},
+		{Name: "bada", UserAgent: []string{deviceBada}},
+		{Name: "tizen", UserAgentAll: []string{deviceTizen, mobile}},
+		{Name: "tizentv", UserAgentAll: []string{deviceTizen, smartTV1}},
+		{Name: "meego", UserAgent: []string{deviceMeego}},
+		{Name: "meegophone", UserAgentAll: []string{deviceMeego, mobi}},
+
+		//First, let's make sure we're NOT on another major mobile OS.
+		{Name: "firefoxos", Rules: []string{"firefoxosphone", "firefoxostablet"}},
+		//KaiOS grew out of Firefox OS and still sends its UA.
+		{Name: "firefoxosphone", ExcludeRules: []string{"ios", "android", "sailfish", "kaios"}, UserAgentAll: []string{engineFirefox, mobile}},
+		{Name: "firefoxostablet", ExcludeRules: []string{"ios", "android", "sailfish", "kaios"}, UserAgentAll: []string{engineFirefox, deviceTablet}},
+		{Name: "sailfish", UserAgent: []string{deviceSailfish}},
+		{Name: "sailfishphone", Requires: []string{"sailfish"}, UserAgentAll: []string{mobile}},
+		{Name: "ubuntu", Rules: []string{"ubuntuphone", "ubuntutablet"}},
+		{Name: "ubuntuphone", UserAgentAll: []string{deviceUbuntu, mobile}},
+		{Name: "ubuntutablet", UserAgentAll: []string{deviceUbuntu, deviceTablet}},
+
+		{Name: "harmonyos", UserAgent: []string{deviceHarmonyOS, deviceOpenHarmony}},
+		{Name: "harmonyosphone", Requires: []string{"harmonyos"}, UserAgent: []string{mobile}, Exclude: []string{deviceTablet}},
+		//HarmonyOS NEXT tablets say 'Tablet', older ones look like Android tablets.
+		{Name: "harmonyostablet", Requires: []string{"harmonyos"}, UserAgent: []string{deviceTablet, deviceAndroid}, Exclude: []string{mobile}},
+		{Name: "kaios", UserAgent: []string{deviceKaiOS}},
+		{Name: "chromeos", UserAgent: []string{deviceChromeOS}},
+		{Name: "foldable", UserAgent: foldableTokens},
+
+		{Name: "dangerhiptop", UserAgent: []string{deviceDanger, deviceHiptop}},
+		{Name: "sonymylo", UserAgentAll: []string{manuSony}, UserAgent: []string{qtembedded, mylocom2}},
+		{Name: "maemotablet", UserAgent: []string{maemo}, Rules: []string{"maemotabletlinux"}},
+		//For Nokia N810, must be Linux + Tablet, or else it could be something else.
+		{Name: "maemotabletlinux", UserAgentAll: []string{linux, deviceTablet}, ExcludeRules: []string{"webostablet", "android"}},
+		{Name: "archos", UserAgent: []string{deviceArchos}},
+
+		{Name: "gameconsole", Rules: []string{"sonyplaystation", "nintendo", "xbox"}},
+		{Name: "sonyplaystation", UserAgent: []string{devicePlaystation}},
+		{Name: "gaminghandheld", UserAgentAll: []string{devicePlaystation, devicePlaystationVita}},
+		{Name: "nintendo", UserAgent: []string{deviceNintendo, deviceWii, deviceNintendoDs}},
+		{Name: "xbox", UserAgent: []string{deviceXbox}},
+		{Name: "brewdevice", UserAgent: []string{deviceBrew}},
+		{Name: "wapwml", Accept: []string{vndwap, wml}},
+		{Name: "midpcapable", UserAgent: []string{deviceMidp}, Accept: []string{deviceMidp}},
+
+		{Name: "bot", UserAgent: botTokens},
+		{Name: "mobilebot", Requires: []string{"bot", "mobilequick"}},
+
+		//*****************************
+		// Device Classes
+		//*****************************
+
+		//Exclude duplicates from TierIphone
+		{
+			Name:  "smartphone",
+			Rules: []string{"tieriphone", "s60ossbrowser", "symbianos", "windowsmobile", "blackberry", "meegophone", "palmwebos"},
+		},
+		//Let's exclude tablets. Then look for smartphones, the 'mobile' catch-all,
+		//  Kindle devices and older feature phone technologies.
+		{
+			Name:         "mobilequick",
+			ExcludeRules: []string{"tiertablet", "tiertenfoot", "wearable", "automotive"},
+			Rules:        []string{"smartphone", "operamobile", "kindle", "amazonsilk", "wapwml", "midpcapable", "brewdevice"},
+			UserAgent:    []string{mobile, engineNetfront, engineUpBrowser},
+		},
+		//Detect older phones from certain manufacturers and operators.
+		{
+			Name:  "mobilelong",
+			Rules: []string{"mobilequick", "gameconsole", "dangerhiptop", "maemotablet", "sonymylo", "archos", "mobilelongpda"},
+			UserAgent: []string{uplink, engineOpenWeb, manuSamsung1, manuSonyEricsson, manuericsson,
+				svcDocomo, svcKddi, svcVodafone},
+		},
+		{Name: "mobilelongpda", UserAgent: []string{devicePda}, Exclude: []string{disUpdate}},
+
+		//*****************************
+		// For Mobile Web Site Design
+		//*****************************
+
+		{
+			Name: "tiertablet",
+			Rules: []string{"ipad", "androidtablet", "blackberrytablet", "firefoxostablet", "ubuntutablet", "webostablet",
+				"firetablet", "harmonyostablet"},
+		},
+		{Name: "tiertenfoot", Rules: []string{"smarttv"}},
+		//Note: BB10 phone is in the list, BB OS 6 and 7 touch phones are in the helper rule.
+		{
+			Name:         "tieriphone",
+			ExcludeRules: []string{"wearable", "automotive"},
+			Rules: []string{"iphoneoripod", "androidphone", "windowsphone", "blackberry10phone", "palmwebos", "bada",
+				"tizen", "firefoxosphone", "sailfishphone", "ubuntuphone", "gaminghandheld", "tieriphoneblackberry",
+				"harmonyosphone"},
+		},
+		{Name: "tieriphoneblackberry", Requires: []string{"blackberrywebkit", "blackberrytouch"}},
+		//Exclude iPhone Tier and e-Ink Kindle devices. Older Windows 'Mobile'
+		//  isn't good enough for iPhone Tier, but is ok here, as are 'High' BlackBerry devices.
+		{
+			Name:         "tierrichcss",
+			Requires:     []string{"mobilequick"},
+			ExcludeRules: []string{"tieriphone", "kindle"},
+			Rules:        []string{"webkit", "s60ossbrowser", "blackberryhigh", "windowsmobile", "kaios"},
+			UserAgent:    []string{engineTelecaQ},
+		},
+		//Exclude devices in the other 2 categories, and the home consoles of the 10-foot tier.
+		{Name: "tierotherphones", Requires: []string{"mobilelong"}, ExcludeRules: []string{"tieriphone", "tierrichcss", "tiertenfoot", "wearable", "automotive"}},
+	}
+}
@@ -0,0 +1,166 @@
+package mobileesp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats accepted by ParseRules.
+const (
+	RulesJSON = "json"
+	RulesYAML = "yaml"
+)
+
+// The version of the rules file format.
+const rulesFileVersion = 1
+
+//**************************
+// Parses a rules file. The file is an object with "version": 1 and a "rules"
+//   list. Each rule is an object with a "name" and any of the list fields
+//   "userAgent", "userAgentAll", "accept", "exclude", "rules", "requires",
+//   "excludeRules" and "categories", matching the fields of Rule.
+//   Errors name the rule and field that is wrong.
+func ParseRules(data []byte, format string) ([]Rule, error) {
+	var document interface{}
+	var err error
+	switch strings.ToLower(format) {
+	case RulesJSON:
+		err = json.Unmarshal(data, &document)
+	case RulesYAML, "yml":
+		err = yaml.Unmarshal(data, &document)
+	default:
+		return nil, fmt.Errorf("mobileesp: unknown rules format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mobileesp: rules file: %w", err)
+	}
+	return rulesFromDocument(document)
+}
+
+//**************************
+// Parses the rules file at path, as JSON or YAML by its extension,
+//   and registers its rules with RegisterRules.
+func LoadRulesFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	rules, err := ParseRules(data, format)
+	if err == nil {
+		err = RegisterRules(rules...)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func rulesFromDocument(document interface{}) ([]Rule, error) {
+	top, ok := document.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("mobileesp: rules file: must be an object with \"version\" and \"rules\"")
+	}
+	for _, key := range sortedKeys(top) {
+		if key != "version" && key != "rules" {
+			return nil, fmt.Errorf("mobileesp: rules file: unknown field %q", key)
+		}
+	}
+
+	switch version := top["version"].(type) {
+	case int:
+		ok = version == rulesFileVersion
+	case float64:
+		ok = version == rulesFileVersion
+	default:
+		ok = false
+	}
+	if !ok {
+		return nil, fmt.Errorf("mobileesp: rules file: \"version\" must be %d", rulesFileVersion)
+	}
+
+	items, ok := top["rules"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("mobileesp: rules file: \"rules\" must be a list")
+	}
+
+	rules := make([]Rule, 0, len(items))
+	names := map[string]bool{}
+	for i, item := range items {
+		rule, err := ruleFromDocument(i, item)
+		if err != nil {
+			return nil, err
+		}
+		name := ruleName(rule.Name)
+		if names[name] {
+			return nil, &RuleError{Rule: name, Field: "name", Err: "is defined twice"}
+		}
+		names[name] = true
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func ruleFromDocument(position int, item interface{}) (Rule, error) {
+	var rule Rule
+	label := fmt.Sprintf("#%d", position)
+
+	fields, ok := item.(map[string]interface{})
+	if !ok {
+		return rule, &RuleError{Rule: label, Err: "must be an object"}
+	}
+	if name, ok := fields["name"].(string); ok && name != "" {
+		rule.Name = name
+		label = name
+	} else {
+		return rule, &RuleError{Rule: label, Field: "name", Err: "must be a non-empty string"}
+	}
+
+	lists := map[string]*[]string{
+		"userAgent":    &rule.UserAgent,
+		"userAgentAll": &rule.UserAgentAll,
+		"accept":       &rule.Accept,
+		"exclude":      &rule.Exclude,
+		"rules":        &rule.Rules,
+		"requires":     &rule.Requires,
+		"excludeRules": &rule.ExcludeRules,
+		"categories":   &rule.Categories,
+	}
+	for _, key := range sortedKeys(fields) {
+		if key == "name" {
+			continue
+		}
+		into, ok := lists[key]
+		if !ok {
+			return rule, &RuleError{Rule: label, Field: key, Err: "unknown field"}
+		}
+		values, ok := fields[key].([]interface{})
+		if !ok {
+			return rule, &RuleError{Rule: label, Field: key, Err: "must be a list of strings"}
+		}
+		for i, value := range values {
+			text, ok := value.(string)
+			if !ok || text == "" {
+				return rule, &RuleError{Rule: label, Field: fmt.Sprintf("%s[%d]", key, i), Err: "must be a non-empty string"}
+			}
+			*into = append(*into, text)
+		}
+	}
+	return rule, nil
+}
+
+func sortedKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,66 @@
+package mobileesp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLoadRulesFile(t *testing.T) {
+	const (
+		fooPad = "Mozilla/5.0 (Linux; U; en-US) AppleWebKit/537.36 (KHTML, like Gecko) FooPad/2.0 Safari/537.36"
+		kindle = "Mozilla/5.0 (Linux; U; en-US) AppleWebKit/528.5+ (KHTML, like Gecko, Safari/528.5+) Version/4.0 Kindle/3.0 (screen 600x800; rotate)"
+	)
+
+	for _, path := range []string{"testdata/rules.yaml", "testdata/rules.json"} {
+		t.Run(path, func(t *testing.T) {
+			t.Cleanup(ResetRules)
+			if err := LoadRulesFile(path); err != nil {
+				t.Fatalf("LoadRulesFile() = %v", err)
+			}
+
+			device := NewFromStrings(fooPad, "").Device()
+			if !device.Is("foopad") || !device.TierTablet() {
+				t.Error("FooPad is not in the tablet tier")
+			}
+			if !NewFromStrings("FooVision/1.0", "").Device().Is("foovision") {
+				t.Error("Is(\"foovision\") = false, want true")
+			}
+			if NewFromStrings(kindle, "").Device().Kindle() {
+				t.Error("Kindle() = true, want the file to replace the built-in rule")
+			}
+		})
+	}
+}
+
+func TestParseRulesErrors(t *testing.T) {
+	tests := []struct {
+		format string
+		data   string
+		want   RuleError
+	}{
+		{RulesYAML, "version: 1\nrules:\n  - userAgent: [foo]\n", RuleError{Rule: "#0", Field: "name"}},
+		{RulesYAML, "version: 1\nrules:\n  - name: foo\n    useragent: [foo]\n", RuleError{Rule: "foo", Field: "useragent"}},
+		{RulesYAML, "version: 1\nrules:\n  - name: foo\n    userAgent: foo\n", RuleError{Rule: "foo", Field: "userAgent"}},
+		{RulesJSON, `{"version": 1, "rules": [{"name": "foo"}, {"name": "bar", "requires": ["x", 2]}]}`, RuleError{Rule: "bar", Field: "requires[1]"}},
+		{RulesJSON, `{"version": 1, "rules": ["foo"]}`, RuleError{Rule: "#0"}},
+		{RulesJSON, `{"version": 1, "rules": [{"name": "foo"}, {"name": "Foo"}]}`, RuleError{Rule: "foo", Field: "name"}},
+	}
+
+	for _, test := range tests {
+		_, err := ParseRules([]byte(test.data), test.format)
+		var ruleErr *RuleError
+		if !errors.As(err, &ruleErr) {
+			t.Errorf("ParseRules(%q) = %v, want a *RuleError", test.data, err)
+			continue
+		}
+		if ruleErr.Rule != test.want.Rule || ruleErr.Field != test.want.Field {
+			t.Errorf("ParseRules(%q) = %v, want rule %q field %q", test.data, err, test.want.Rule, test.want.Field)
+		}
+	}
+
+	for _, data := range []string{"rules: []\n", "version: 2\nrules: []\n", "version: 1\nrules: foo\n", "version: 1\nrule: []\n", "- name: foo\n"} {
+		if _, err := ParseRules([]byte(data), RulesYAML); err == nil {
+			t.Errorf("ParseRules(%q) = nil error", data)
+		}
+	}
+}
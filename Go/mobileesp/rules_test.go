@@ -0,0 +1,91 @@
+package mobileesp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRegisterRulesExtendsCategory(t *testing.T) {
+	t.Cleanup(ResetRules)
+
+	const userAgent = "Mozilla/5.0 (Linux; U; en-US) AppleWebKit/537.36 (KHTML, like Gecko) FooPad/2.0 Safari/537.36"
+	if NewFromStrings(userAgent, "").Device().TierTablet() {
+		t.Fatal("TierTablet() = true before the rule was registered")
+	}
+
+	err := RegisterRules(Rule{Name: "foopad", UserAgent: []string{"FooPad"}, Categories: []string{"tiertablet"}})
+	if err != nil {
+		t.Fatalf("RegisterRules() = %v", err)
+	}
+
+	device := NewFromStrings(userAgent, "").Device()
+	if !device.Is("foopad") || !device.Is("FooPad") || !device.Is(" foopad") {
+		t.Error("Is(\"foopad\") = false, want true")
+	}
+	if !device.TierTablet() {
+		t.Error("TierTablet() = false, want true for a tiertablet member")
+	}
+	if device.Is("nosuchrule") {
+		t.Error("Is() = true for an unknown rule")
+	}
+}
+
+func TestRegisterRulesReplacesBuiltin(t *testing.T) {
+	t.Cleanup(ResetRules)
+
+	const userAgent = "Mozilla/5.0 (Linux; U; en-US) AppleWebKit/528.5+ (KHTML, like Gecko, Safari/528.5+) Version/4.0 Kindle/3.0 (screen 600x800; rotate)"
+	if !NewFromStrings(userAgent, "").Device().Kindle() {
+		t.Fatal("Kindle() = false with the built-in rules")
+	}
+
+	//Names match case-insensitively, so this replaces the built-in rule instead of adding one.
+	if err := RegisterRules(Rule{Name: " Kindle", UserAgent: []string{"kindle/4"}}); err != nil {
+		t.Fatalf("RegisterRules() = %v", err)
+	}
+	if NewFromStrings(userAgent, "").Device().Kindle() {
+		t.Error("Kindle() = true, want the replacement rule to reject Kindle/3.0")
+	}
+	if got, want := len(ActiveRules()), len(DefaultRules()); got != want {
+		t.Errorf("RegisterRules() added a rule: %d rules, want %d", got, want)
+	}
+
+	ResetRules()
+	if !NewFromStrings(userAgent, "").Device().Kindle() {
+		t.Error("Kindle() = false after ResetRules()")
+	}
+}
+
+func TestRegisterRulesErrors(t *testing.T) {
+	t.Cleanup(ResetRules)
+
+	tests := []struct {
+		rules []Rule
+		want  RuleError
+	}{
+		{[]Rule{{Name: "", UserAgent: []string{"x"}}}, RuleError{Rule: fmt.Sprintf("#%d", len(DefaultRules())), Field: "name"}},
+		{[]Rule{{Name: "foo", Requires: []string{"bar"}}}, RuleError{Rule: "foo", Field: "requires"}},
+		{[]Rule{{Name: "foo", UserAgent: []string{""}}}, RuleError{Rule: "foo", Field: "userAgent"}},
+		{[]Rule{{Name: "foo", Categories: []string{"bar"}}}, RuleError{Rule: "foo", Field: "categories"}},
+		{[]Rule{{Name: "foo", Rules: []string{"foo"}}}, RuleError{Rule: "foo", Err: "depends on itself"}},
+		{[]Rule{{Name: "tiertablet", Rules: []string{"mobilequick"}}}, RuleError{Err: "depends on itself"}},
+		{[]Rule{{Name: "foo", UserAgent: []string{"x"}}, {Name: " FOO", UserAgent: []string{"y"}}}, RuleError{Rule: "foo", Field: "name", Err: "is defined twice"}},
+	}
+
+	for _, test := range tests {
+		err := RegisterRules(test.rules...)
+		var ruleErr *RuleError
+		if !errors.As(err, &ruleErr) {
+			t.Errorf("RegisterRules(%+v) = %v, want a *RuleError", test.rules, err)
+			continue
+		}
+		if test.want.Rule != "" && ruleErr.Rule != test.want.Rule || ruleErr.Field != test.want.Field ||
+			test.want.Err != "" && ruleErr.Err != test.want.Err {
+			t.Errorf("RegisterRules(%+v) = %v, want rule %q field %q", test.rules, err, test.want.Rule, test.want.Field)
+		}
+	}
+
+	if got, want := len(ActiveRules()), len(DefaultRules()); got != want {
+		t.Errorf("failed RegisterRules() changed the active rules: %d rules, want %d", got, want)
+	}
+}
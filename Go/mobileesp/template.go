@@ -0,0 +1,75 @@
+package mobileesp
+
+import (
+	"context"
+	"html/template"
+	"io"
+	"net/http"
+)
+
+//**************************
+// Returns template functions that answer for the detection stored in ctx
+//   by Middleware. Without one they answer for an empty User Agent, so
+//   every is* function reports false.
+//
+// Templates need the functions when they are parsed, so parse with
+//   TemplateFuncs(context.Background()) and bind each request with
+//   ExecuteTemplate, or with Clone() and Funcs(TemplateFuncs(r.Context())):
+//
+//	{{if isTablet}}{{template "tablet-nav"}}{{else if isTierIphone}}{{template "touch-nav"}}{{end}}
+//	{{if and (eq platform "ios") (osVersionAtLeast 16 4)}}<link rel="manifest" href="/app.json">{{end}}
+//
+// The functions are:
+//   - isMobile, isTablet, isTierIphone, isRichCss, isOtherPhone, isTenFoot
+//     and isBot: MobileQuick(), TierTablet(), TierIphone(), TierRichCss(),
+//     TierOtherPhones(), TierTenFoot() and Bot().
+//   - is "name": Device().Is(name), for any rule.
+//   - tier, platform, formFactor, browser, vendor and model: the value as a string.
+//   - osVersion and browserVersion: the version as a string, such as "16.4.0".
+//   - osVersionAtLeast and browserVersionAtLeast major [minor [patch]]: Version.AtLeast().
+func TemplateFuncs(ctx context.Context) template.FuncMap {
+	detect, ok := FromContext(ctx)
+	if !ok {
+		detect = NewFromStrings("", "")
+	}
+	device := detect.Device()
+
+	return template.FuncMap{
+		"isMobile":     device.MobileQuick,
+		"isTablet":     device.TierTablet,
+		"isTierIphone": device.TierIphone,
+		"isRichCss":    device.TierRichCss,
+		"isOtherPhone": device.TierOtherPhones,
+		"isTenFoot":    device.TierTenFoot,
+		"isBot":        device.Bot,
+		"is":           device.Is,
+
+		"tier":       func() string { return string(detect.Classify().Tier) },
+		"platform":   func() string { return string(detect.Classify().Platform) },
+		"formFactor": func() string { return string(detect.Classify().FormFactor) },
+		"browser":    func() string { return string(detect.Browser().Family) },
+		"vendor":     func() string { return string(detect.Vendor()) },
+		"model":      detect.Model,
+
+		"osVersion":      func() string { return detect.OSVersion().String() },
+		"browserVersion": func() string { return detect.BrowserVersion().String() },
+		"osVersionAtLeast": func(major int, minorAndPatch ...int) bool {
+			return detect.OSVersion().AtLeast(major, minorAndPatch...)
+		},
+		"browserVersionAtLeast": func(major int, minorAndPatch ...int) bool {
+			return detect.BrowserVersion().AtLeast(major, minorAndPatch...)
+		},
+	}
+}
+
+//**************************
+// Executes the template called name with the functions of TemplateFuncs
+//   bound to the detection of r. tmpl must have been parsed with
+//   TemplateFuncs and must not have been executed yet, so it can be cloned.
+func ExecuteTemplate(w io.Writer, r *http.Request, tmpl *template.Template, name string, data interface{}) error {
+	clone, err := tmpl.Clone()
+	if err != nil {
+		return err
+	}
+	return clone.Funcs(TemplateFuncs(r.Context())).ExecuteTemplate(w, name, data)
+}
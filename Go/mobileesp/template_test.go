@@ -0,0 +1,52 @@
+package mobileesp
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	const page = `{{if isTablet}}tablet{{else if isTierIphone}}iphone{{else}}desktop{{end}}` +
+		` {{platform}} {{osVersion}} {{osVersionAtLeast 16 4}} {{osVersionAtLeast 17}} {{is "ios"}} {{vendor}}`
+	tmpl := template.Must(template.New("page").Funcs(TemplateFuncs(context.Background())).Parse(page))
+
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := ExecuteTemplate(w, r, tmpl, "page", nil); err != nil {
+			t.Fatal(err)
+		}
+	}))
+
+	tests := []struct {
+		userAgent string
+		want      string
+	}{
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Mobile/15E148 Safari/604.1", "iphone ios 16.5.0 true false true apple"},
+		{"Mozilla/5.0 (iPad; U; CPU OS 4_3_1 like Mac OS X; en-us) AppleWebKit/533.17.9 (KHTML, like Gecko) Version/5.0.2 Mobile/8G4 Safari/6533.18.5", "tablet ios 4.3.1 false false true apple"},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36", "desktop   false false false "},
+	}
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set("User-Agent", test.userAgent)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if got := recorder.Body.String(); got != test.want {
+			t.Errorf("template = %q, want %q\nUA: %s", got, test.want, test.userAgent)
+		}
+	}
+}
+
+func TestTemplateFuncsWithoutMiddleware(t *testing.T) {
+	tmpl := template.Must(template.New("page").Funcs(TemplateFuncs(context.Background())).Parse(`{{isMobile}} {{tier}}|{{browserVersionAtLeast 1}}`))
+
+	var out strings.Builder
+	if err := ExecuteTemplate(&out, httptest.NewRequest(http.MethodGet, "/", nil), tmpl, "page", nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != "false |false" {
+		t.Errorf("template = %q, want every function to report nothing", got)
+	}
+}
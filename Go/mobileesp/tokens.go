@@ -0,0 +1,133 @@
+package mobileesp
+
+//**************************
+// tokenMatcher finds every token of a ruleset in one pass over a header.
+//   It is an Aho-Corasick automaton compiled to a table: each byte of the
+//   header costs one lookup, however many tokens the rules use. Bytes that
+//   appear in no token share one column of the table.
+type tokenMatcher struct {
+	tokens  []string
+	ids     map[string]int
+	classes [256]uint16 //The table column of each byte.
+	width   int         //The number of columns.
+	next    []int32     //The state after reading a byte: next[state*width+column].
+	found   [][]int32   //The tokens that end at each state.
+}
+
+// bitset holds one bit per token of a tokenMatcher.
+type bitset []uint64
+
+func (set bitset) has(i int) bool {
+	return set[i/64]&(1<<(uint(i)%64)) != 0
+}
+
+func (set bitset) set(i int) {
+	set[i/64] |= 1 << (uint(i) % 64)
+}
+
+func newTokenMatcher() *tokenMatcher {
+	return &tokenMatcher{ids: map[string]int{}}
+}
+
+//**************************
+// Returns the id of token, adding it if it is new. Call before build().
+func (matcher *tokenMatcher) add(token string) int {
+	if id, ok := matcher.ids[token]; ok {
+		return id
+	}
+	id := len(matcher.tokens)
+	matcher.ids[token] = id
+	matcher.tokens = append(matcher.tokens, token)
+	return id
+}
+
+//**************************
+// Compiles the tokens added so far into the transition table.
+func (matcher *tokenMatcher) build() {
+	matcher.width = 1
+	for _, token := range matcher.tokens {
+		for i := 0; i < len(token); i++ {
+			if matcher.classes[token[i]] == 0 {
+				matcher.classes[token[i]] = uint16(matcher.width)
+				matcher.width++
+			}
+		}
+	}
+
+	//Build the trie. -1 marks a missing transition until the links are filled in.
+	matcher.next = nil
+	matcher.found = nil
+	newState := func() int32 {
+		state := int32(len(matcher.found))
+		for i := 0; i < matcher.width; i++ {
+			matcher.next = append(matcher.next, -1)
+		}
+		matcher.found = append(matcher.found, nil)
+		return state
+	}
+	newState()
+	for id, token := range matcher.tokens {
+		state := int32(0)
+		for i := 0; i < len(token); i++ {
+			edge := int(state)*matcher.width + int(matcher.classes[token[i]])
+			if matcher.next[edge] == -1 {
+				child := newState()
+				matcher.next[edge] = child
+			}
+			state = matcher.next[edge]
+		}
+		matcher.found[state] = append(matcher.found[state], int32(id))
+	}
+
+	//Breadth first, point each missing transition at the one of the longest
+	//  suffix state, and give each state the tokens of that suffix too.
+	fail := make([]int32, len(matcher.found))
+	queue := []int32{}
+	for column := 0; column < matcher.width; column++ {
+		child := matcher.next[column]
+		if child == -1 {
+			matcher.next[column] = 0
+		} else if child != 0 {
+			queue = append(queue, child)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for column := 0; column < matcher.width; column++ {
+			edge := int(state)*matcher.width + column
+			suffix := matcher.next[int(fail[state])*matcher.width+column]
+			child := matcher.next[edge]
+			if child == -1 {
+				matcher.next[edge] = suffix
+				continue
+			}
+			fail[child] = suffix
+			matcher.found[child] = append(matcher.found[child], matcher.found[suffix]...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+//**************************
+// Returns the set of tokens that text contains.
+func (matcher *tokenMatcher) scan(text string) bitset {
+	set := make(bitset, matcher.words())
+	matcher.scanInto(text, set)
+	return set
+}
+
+// The length of a bitset for the tokens.
+func (matcher *tokenMatcher) words() int {
+	return (len(matcher.tokens) + 63) / 64
+}
+
+func (matcher *tokenMatcher) scanInto(text string, set bitset) {
+	state := int32(0)
+	for i := 0; i < len(text); i++ {
+		state = matcher.next[int(state)*matcher.width+int(matcher.classes[text[i]])]
+		for _, id := range matcher.found[state] {
+			set.set(int(id))
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package mobileesp
+
+import (
+	"strings"
+	"testing"
+)
+
+var benchmarkUserAgents = []string{
+	"Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Mobile/15E148 Safari/604.1",
+	"Mozilla/5.0 (Linux; Android 13; SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36",
+	"Mozilla/5.0 (Linux; Android 12; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Safari/605.1.15",
+	"Mozilla/5.0 (BlackBerry; U; BlackBerry 9800; en) AppleWebKit/534.1+ (KHTML, like Gecko) Version/6.0.0.337 Mobile Safari/534.1+",
+	"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+	"Nokia6230i/2.0 (03.25) Profile/MIDP-2.0 Configuration/CLDC-1.1",
+}
+
+func TestTokenMatcherFindsEveryToken(t *testing.T) {
+	userAgents := append([]string{"", "mobilemobi", "ipadipodiphone", "windows ce; ppc; mac_powerpc"}, benchmarkUserAgents...)
+	userAgents = append(userAgents, tabletUserAgents...)
+
+	matcher := currentRules().userAgent
+	for _, userAgent := range userAgents {
+		userAgent = strings.ToLower(userAgent)
+		found := matcher.scan(userAgent)
+		for id, token := range matcher.tokens {
+			if got, want := found.has(id), strings.Contains(userAgent, token); got != want {
+				t.Errorf("scan(%q) found %q = %v, want %v", userAgent, token, got, want)
+			}
+		}
+	}
+}
+
+// Both sides of BenchmarkDetection must reach the same results.
+func TestHandCodedAgrees(t *testing.T) {
+	for _, userAgent := range benchmarkUserAgents {
+		device := NewFromStrings(userAgent, "").Device()
+		handCoded := newHandCoded(userAgent, "")
+		checks := []struct {
+			name           string
+			got, handCoded bool
+		}{
+			{"Webkit", device.Webkit(), handCoded.Webkit()},
+			{"Iphone", device.Iphone(), handCoded.Iphone()},
+			{"Android", device.Android(), handCoded.Android()},
+			{"AndroidPhone", device.AndroidPhone(), handCoded.AndroidPhone()},
+			{"MobileQuick", device.MobileQuick(), handCoded.MobileQuick()},
+			{"TierIphone", device.TierIphone(), handCoded.TierIphone()},
+			{"TierTablet", device.TierTablet(), handCoded.TierTablet()},
+			{"TierRichCss", device.TierRichCss(), handCoded.TierRichCss()},
+			{"TierOtherPhones", device.TierOtherPhones(), handCoded.TierOtherPhones()},
+		}
+		for _, check := range checks {
+			if check.got != check.handCoded {
+				t.Errorf("%s() = %v, hand-coded %v\nUA: %s", check.name, check.got, check.handCoded, userAgent)
+			}
+		}
+	}
+}
+
+// Compares NewFromStrings with the hand-coded detection it replaced, kept in
+// handcoded_test.go. Both run the detections of initDeviceScan.
+func BenchmarkDetection(b *testing.B) {
+	b.Run("rules", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewFromStrings(benchmarkUserAgents[i%len(benchmarkUserAgents)], "")
+		}
+	})
+	b.Run("hand-coded", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			newHandCoded(benchmarkUserAgents[i%len(benchmarkUserAgents)], "")
+		}
+	})
+}
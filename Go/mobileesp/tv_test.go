@@ -0,0 +1,47 @@
+package mobileesp
+
+import "testing"
+
+func TestSmartTV(t *testing.T) {
+	tests := []struct {
+		userAgent  string
+		method     string //The Device method that also matches.
+		formFactor FormFactor
+	}{
+		{"Mozilla/5.0 (Linux; Android 11; SHIELD Android TV Build/RQ1A.210105.003; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/114.0.5735.196 Mobile Safari/537.36", "androidtv", FormFactorTV},
+		{"Mozilla/5.0 (Linux; Android 9; AFTMM Build/PS7233) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36", "firetv", FormFactorTV},
+		{"Roku/DVP-12.0 (12.0.0.4182-88)", "rokutv", FormFactorTV},
+		{"AppleCoreMedia/1.0.0.20K71 (Apple TV; U; CPU OS 16_1 like Mac OS X; en_us)", "appletv", FormFactorTV},
+		{"Mozilla/5.0 (Linux; Android 12.0; Build/STTL.240206.002) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36 CrKey/1.56.500000 DeviceType/AndroidTV", "chromecast", FormFactorTV},
+		{"Mozilla/5.0 (Linux; Tizen 2.3) AppleWebKit/538.1 (KHTML, like Gecko)Version/2.3 TV Safari/538.1 HbbTV/1.2.1 (;Samsung;SmartTV2015;T-HKM6DEUC-1506.0;;)", "hbbtv", FormFactorTV},
+		{"Mozilla/5.0 (Linux; VIDAA/6.0(Hisense;SmartTV;65A6H;HU65N6800UWG/V0000.06.12A.N0406)) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/63.0.3239.0 Safari/537.36", "vidaa", FormFactorTV},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64; Xbox; Xbox One) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36 Edge/44.18363.8131", "xbox", FormFactorConsole},
+	}
+
+	for _, test := range tests {
+		detect := NewFromStrings(test.userAgent, "")
+		device := detect.Device()
+		if !device.SmartTV() || !device.TierTenFoot() || !device.Is(test.method) {
+			t.Errorf("SmartTV() = %v, TierTenFoot() = %v, Is(%q) = %v, want all true\nUA: %s",
+				device.SmartTV(), device.TierTenFoot(), test.method, device.Is(test.method), test.userAgent)
+		}
+		if device.MobileQuick() || device.TierTablet() || device.TierIphone() || device.TierOtherPhones() {
+			t.Errorf("a TV is also in a mobile tier: %+v\nUA: %s", detect.Classify(), test.userAgent)
+		}
+		if info := detect.Classify(); info.FormFactor != test.formFactor || info.Tier != TierTenFoot {
+			t.Errorf("Classify() = %+v, want %q in the 10-foot tier\nUA: %s", info, test.formFactor, test.userAgent)
+		}
+	}
+}
+
+func TestHandheldConsoleIsNotTV(t *testing.T) {
+	for _, userAgent := range []string{
+		"Mozilla/5.0 (Playstation Vita 2.02) AppleWebKit/536.26 (KHTML, like Gecko) Silk/3.2",
+		"Mozilla/5.0 (Nintendo 3DS; U; ; de) Version/1.7455.EU",
+		"Mozilla/4.0 (PSP (PlayStation Portable); 2.00)",
+	} {
+		if device := NewFromStrings(userAgent, "").Device(); device.SmartTV() || device.TierTenFoot() {
+			t.Errorf("SmartTV() = true for the handheld %q", userAgent)
+		}
+	}
+}
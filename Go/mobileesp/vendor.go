@@ -0,0 +1,236 @@
+package mobileesp
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Vendor is the canonical device manufacturer reported by Vendor().
+type Vendor string
+
+const (
+	VendorUnknown      Vendor = ""
+	VendorApple        Vendor = "apple"
+	VendorSamsung      Vendor = "samsung"
+	VendorGoogle       Vendor = "google"
+	VendorXiaomi       Vendor = "xiaomi" //Includes the Redmi and POCO brands.
+	VendorHuawei       Vendor = "huawei"
+	VendorHonor        Vendor = "honor"
+	VendorBlackBerry   Vendor = "blackberry"
+	VendorAmazon       Vendor = "amazon"
+	VendorSony         Vendor = "sony"
+	VendorSonyEricsson Vendor = "sonyericsson"
+	VendorHTC          Vendor = "htc"
+	VendorLG           Vendor = "lg"
+	VendorMotorola     Vendor = "motorola"
+	VendorNokia        Vendor = "nokia"
+	VendorMicrosoft    Vendor = "microsoft"
+	VendorOnePlus      Vendor = "oneplus"
+	VendorOppo         Vendor = "oppo"
+	VendorVivo         Vendor = "vivo"
+	VendorRealme       Vendor = "realme"
+	VendorAsus         Vendor = "asus"
+	VendorLenovo       Vendor = "lenovo"
+	VendorZTE          Vendor = "zte"
+)
+
+// The names manufacturers go by in UAs, mapped to their canonical vendor.
+var vendorAliases = map[string]Vendor{
+	"apple":        VendorApple,
+	"samsung":      VendorSamsung,
+	"google":       VendorGoogle,
+	"xiaomi":       VendorXiaomi,
+	"redmi":        VendorXiaomi,
+	"poco":         VendorXiaomi,
+	"huawei":       VendorHuawei,
+	"honor":        VendorHonor,
+	"blackberry":   VendorBlackBerry,
+	"rim":          VendorBlackBerry,
+	"amazon":       VendorAmazon,
+	"sony":         VendorSony,
+	"sonyericsson": VendorSonyEricsson,
+	"htc":          VendorHTC,
+	"lg":           VendorLG,
+	"lge":          VendorLG,
+	"motorola":     VendorMotorola,
+	"nokia":        VendorNokia,
+	"microsoft":    VendorMicrosoft,
+	"oneplus":      VendorOnePlus,
+	"oppo":         VendorOppo,
+	"vivo":         VendorVivo,
+	"realme":       VendorRealme,
+	"asus":         VendorAsus,
+	"lenovo":       VendorLenovo,
+	"zte":          VendorZTE,
+}
+
+//**************************
+// Model numbers and names that identify their manufacturer, tried in order.
+var modelVendors = []struct {
+	vendor  Vendor
+	pattern *regexp.Regexp
+}{
+	{VendorSamsung, regexp.MustCompile(`(?i)^(?:samsung|galaxy|(?:sm|gt|sgh|sch|sph|shv|shw)-)`)},
+	{VendorGoogle, regexp.MustCompile(`(?i)^(?:pixel|nexus)`)},
+	{VendorXiaomi, regexp.MustCompile(`(?i)^(?:xiaomi|redmi|poco|mi |m\d{4}[a-z]\d+[a-z]*$|2\d{5,}[a-z]+$)`)},
+	{VendorHonor, regexp.MustCompile(`(?i)^honor`)},
+	{VendorHuawei, regexp.MustCompile(`(?i)^(?:huawei|[a-z]{3}-(?:l|al|tl|lx|w|n|an)\d)`)},
+	{VendorAmazon, regexp.MustCompile(`(?i)^(?:kf[a-z]{2,}|aft[a-z]+)$`)},
+	{VendorBlackBerry, regexp.MustCompile(`(?i)^(?:blackberry|bb[a-z]\d{3})`)},
+	{VendorHTC, regexp.MustCompile(`(?i)^htc`)},
+	{VendorLG, regexp.MustCompile(`(?i)^(?:lg|lm)-`)},
+	{VendorMotorola, regexp.MustCompile(`(?i)^(?:moto|xt\d{4})`)},
+	{VendorNokia, regexp.MustCompile(`(?i)^(?:nokia|lumia|ta-\d{4})`)},
+	{VendorOnePlus, regexp.MustCompile(`(?i)^(?:oneplus|(?:hd|gm|in|kb|le|ne|pj)\d{4}$)`)},
+	{VendorOppo, regexp.MustCompile(`(?i)^(?:oppo|(?:cph|ph[a-z])\d{4}$)`)},
+	{VendorRealme, regexp.MustCompile(`(?i)^(?:realme|rmx\d{4}$)`)},
+	{VendorVivo, regexp.MustCompile(`(?i)^(?:vivo|v\d{4}[a-z]*$)`)},
+	{VendorSony, regexp.MustCompile(`(?i)^(?:sony|so-\d{2}[a-z]|xq-[a-z]{2}\d{2})`)},
+	{VendorAsus, regexp.MustCompile(`(?i)^(?:asus|zenfone)`)},
+	{VendorLenovo, regexp.MustCompile(`(?i)^lenovo`)},
+	{VendorZTE, regexp.MustCompile(`(?i)^zte`)},
+}
+
+// Patterns matched against the User Agent as sent, keeping the case of the model.
+var (
+	//Android puts the model in the segment before Build/, as in
+	//  "(Linux; U; Android 4.0.3; en-us; KFTT Build/IML74K)".
+	androidBuildModel = regexp.MustCompile(`(?i); ?([^;()]+?) build/`)
+	//Newer Android UAs leave out Build/, as in "(Linux; Android 13; SM-S918B)".
+	androidModel       = regexp.MustCompile(`(?i)android [\d.]+; ([^;()]+)\)`)
+	iosModel           = regexp.MustCompile(`\b(?:iPhone|iPad|iPod)\d+,\d+`)
+	blackBerryModel    = regexp.MustCompile(`(?i)blackberry ?(\d{4})`)
+	bb10Model          = regexp.MustCompile(`\(BB10; (\w+)\)`)
+	windowsPhoneModel  = regexp.MustCompile(`(?i)iemobile[/ ][\d.]+;(?: arm;)?(?: touch;)? ([^;]+); ([^;)]+)`)
+	featurePhoneModel  = regexp.MustCompile(`(?:^|[ ;(])(Nokia|SonyEricsson|SAMSUNG-)([A-Za-z0-9-]+)`)
+	androidPlaceholder = regexp.MustCompile(`(?i)^(?:k|u|wv|mobile|tablet|linux|[a-z]{2}(?:[-_][a-z]{2})?)$`)
+)
+
+//**************************
+// Returns the canonical manufacturer, such as VendorSamsung for a
+//   "SAMSUNG SM-S918B" UA. Returns VendorUnknown when neither the model
+//   nor a manufacturer token gives it away.
+func (base *UAgentInfo) Vendor() Vendor {
+	vendor, _ := base.vendorModel()
+	return vendor
+}
+
+//**************************
+// Returns the device model as the UA names it, such as "SM-S918B",
+//   "Pixel 7" or "BlackBerry 9800". Apple devices report "iPhone", "iPad"
+//   or "iPod touch" unless an app names the hardware, as in "iPhone14,5".
+//   A Sec-CH-UA-Model hint wins over the User Agent. Returns "" when the
+//   UA doesn't name a model.
+func (base *UAgentInfo) Model() string {
+	_, model := base.vendorModel()
+	return model
+}
+
+func (base *UAgentInfo) vendorModel() (Vendor, string) {
+	vendor, model := VendorUnknown, strings.TrimSpace(base.clientHints.Model)
+	if model == "" {
+		vendor, model = matchModel(base.userAgent, base.Device())
+	}
+	if vendor == VendorUnknown && model != "" {
+		vendor = modelVendor(model)
+	}
+	if vendor == VendorUnknown {
+		vendor = tokenVendor(base.userAgentHeader)
+	}
+	return vendor, model
+}
+
+//**************************
+// Finds the model in the User Agent as sent. The vendor is set only
+//   when the UA layout names it apart from the model.
+func matchModel(userAgent string, device Device) (Vendor, string) {
+	switch {
+	//Windows Phone 8.1 also claims Android and iPhone.
+	case device.WindowsPhone():
+		if match := windowsPhoneModel.FindStringSubmatch(userAgent); match != nil {
+			return vendorAliases[strings.ToLower(strings.TrimSpace(match[1]))], strings.TrimSpace(match[2])
+		}
+	case device.IphoneOrIpod() || device.Ipad():
+		if model := iosModel.FindString(userAgent); model != "" {
+			return VendorApple, model
+		}
+		switch {
+		case device.Ipad():
+			return VendorApple, "iPad"
+		case device.Ipod():
+			return VendorApple, "iPod touch"
+		}
+		return VendorApple, "iPhone"
+	case device.BlackBerry() || device.BlackBerryTouch():
+		if match := blackBerryModel.FindStringSubmatch(userAgent); match != nil {
+			return VendorBlackBerry, "BlackBerry " + match[1]
+		}
+		if match := bb10Model.FindStringSubmatch(userAgent); match != nil {
+			return VendorBlackBerry, "BB10 " + match[1]
+		}
+		return VendorBlackBerry, ""
+	case device.BlackBerryTablet():
+		return VendorBlackBerry, "PlayBook"
+	case device.Android() || device.FireOS():
+		match := androidBuildModel.FindStringSubmatch(userAgent)
+		if match == nil {
+			match = androidModel.FindStringSubmatch(userAgent)
+		}
+		if match != nil && !androidPlaceholder.MatchString(strings.TrimSpace(match[1])) {
+			return androidModelName(strings.TrimSpace(match[1]))
+		}
+	case device.Kindle():
+		return VendorAmazon, "Kindle"
+	}
+
+	if match := featurePhoneModel.FindStringSubmatch(userAgent); match != nil {
+		vendor := vendorAliases[strings.ToLower(strings.TrimSuffix(match[1], "-"))]
+		return vendor, match[2]
+	}
+	return VendorUnknown, ""
+}
+
+//**************************
+// Samsung Internet adds "SAMSUNG" before the model number, as in
+//   "SAMSUNG SM-S918B". The model is the number alone.
+func androidModelName(model string) (Vendor, string) {
+	if len(model) > len("samsung ") && strings.EqualFold(model[:len("samsung ")], "samsung ") {
+		return VendorSamsung, strings.TrimSpace(model[len("samsung "):])
+	}
+	return VendorUnknown, model
+}
+
+func modelVendor(model string) Vendor {
+	for _, candidate := range modelVendors {
+		if candidate.pattern.MatchString(model) {
+			return candidate.vendor
+		}
+	}
+	return VendorUnknown
+}
+
+//**************************
+// The last resort: a manufacturer token anywhere in the lower case UA.
+func tokenVendor(userAgent string) Vendor {
+	switch {
+	case strings.Contains(userAgent, manuSonyEricsson):
+		return VendorSonyEricsson
+	case strings.Contains(userAgent, manuSamsung1) || strings.Contains(userAgent, "samsung"):
+		return VendorSamsung
+	case strings.Contains(userAgent, manuHtc):
+		return VendorHTC
+	case strings.Contains(userAgent, "nokia"):
+		return VendorNokia
+	case strings.Contains(userAgent, deviceBB) || strings.Contains(userAgent, deviceBB10):
+		return VendorBlackBerry
+	case strings.Contains(userAgent, deviceHarmonyOS) || strings.Contains(userAgent, "huawei"):
+		return VendorHuawei
+	case strings.Contains(userAgent, deviceKindle) || strings.Contains(userAgent, engineSilk):
+		return VendorAmazon
+	case strings.Contains(userAgent, "xiaomi") || strings.Contains(userAgent, "miuibrowser"):
+		return VendorXiaomi
+	case strings.Contains(userAgent, manuSony):
+		return VendorSony
+	}
+	return VendorUnknown
+}
@@ -0,0 +1,49 @@
+package mobileesp
+
+import "testing"
+
+func TestVendorModel(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		vendor    Vendor
+		model     string
+	}{
+		{"Mozilla/5.0 (Linux; Android 13; SAMSUNG SM-S918B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/21.0 Chrome/110.0.5481.154 Mobile Safari/537.36", VendorSamsung, "SM-S918B"},
+		{"Mozilla/5.0 (Linux; Android 12; SM-G991B Build/SP1A.210812.016; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/114.0.5735.196 Mobile Safari/537.36", VendorSamsung, "SM-G991B"},
+		{"Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Mobile Safari/537.36", VendorGoogle, "Pixel 7"},
+		{"Mozilla/5.0 (Linux; Android 12; Redmi Note 11 Build/SKQ1.211103.001) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Mobile Safari/537.36", VendorXiaomi, "Redmi Note 11"},
+		{"Mozilla/5.0 (Linux; Android 12; 2201116SG) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Mobile Safari/537.36", VendorXiaomi, "2201116SG"},
+		{"Mozilla/5.0 (Linux; Android 10; VOG-L29) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36 OPR/76.2.4027.73374", VendorHuawei, "VOG-L29"},
+		{"Mozilla/5.0 (Linux; Android 10; HD1913) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36 EdgA/114.0.1823.67", VendorOnePlus, "HD1913"},
+		{"Mozilla/5.0 (Linux; U; Android 10; en-US; RMX2020 Build/QP1A.190711.020) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/78.0.3904.108 UCBrowser/13.4.0.1306 Mobile Safari/537.36", VendorRealme, "RMX2020"},
+		{"Mozilla/5.0 (Linux; U; Android 4.0.3; ko-kr; LG-L160L Build/IML74K) AppleWebkit/534.30 (KHTML, like Gecko) Version/4.0 Mobile Safari/534.30", VendorLG, "LG-L160L"},
+		{"Mozilla/5.0 (Linux; U; Android 4.0.3; en-us; KFTT Build/IML74K) AppleWebKit/535.19 (KHTML, like Gecko) Silk/2.1 Mobile Safari/535.19 Silk-Accelerated=true", VendorAmazon, "KFTT"},
+		{"Mozilla/5.0 (BlackBerry; U; BlackBerry 9800; en-US) AppleWebKit/534.1+ (KHTML, like Gecko) Version/6.0.0.246 Mobile Safari/534.1+", VendorBlackBerry, "BlackBerry 9800"},
+		{"BlackBerry9700/5.0.0.351 Profile/MIDP-2.1 Configuration/CLDC-1.1 VendorID/123", VendorBlackBerry, "BlackBerry 9700"},
+		{"Mozilla/5.0 (BB10; Touch) AppleWebKit/537.10+ (KHTML, like Gecko) Version/10.0.9.2372 Mobile Safari/537.10+", VendorBlackBerry, "BB10 Touch"},
+		{"Mozilla/5.0 (PlayBook; U; RIM Tablet OS 1.0.0; en-US) AppleWebKit/534.11+ (KHTML, like Gecko) Version/7.1.0.7 Safari/534.11+", VendorBlackBerry, "PlayBook"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.5 Mobile/15E148 Safari/604.1", VendorApple, "iPhone"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 16_5 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Mobile/15E148 Instagram 289.0.0.25.109 (iPhone14,5; iOS 16_5; en_US; en; scale=3.00; 1170x2532; 489393226)", VendorApple, "iPhone14,5"},
+		{"Mozilla/5.0 (iPad; U; CPU OS 4_3_1 like Mac OS X; en-us) AppleWebKit/533.17.9 (KHTML, like Gecko) Version/5.0.2 Mobile/8G4 Safari/6533.18.5", VendorApple, "iPad"},
+		{"Mozilla/5.0 (Mobile; Windows Phone 8.1; Android 4.0; ARM; Trident/7.0; Touch; rv:11.0; IEMobile/11.0; NOKIA; Lumia 635) like iPhone OS 7_0_3 Mac OS X AppleWebKit/537 (KHTML, like Gecko) Mobile Safari/537", VendorNokia, "Lumia 635"},
+		{"SonyEricssonK750i/R1AA Browser/SEMC-Browser/4.2 Profile/MIDP-2.0 Configuration/CLDC-1.1", VendorSonyEricsson, "K750i"},
+		{"Mozilla/5.0 (Android 13; Mobile; rv:109.0) Gecko/114.0 Firefox/114.0", VendorUnknown, ""},
+		{"Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Mobile Safari/537.36", VendorUnknown, ""},
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Safari/537.36", VendorUnknown, ""},
+	}
+
+	for _, test := range tests {
+		detect := NewFromStrings(test.userAgent, "")
+		if vendor, model := detect.Vendor(), detect.Model(); vendor != test.vendor || model != test.model {
+			t.Errorf("Vendor(), Model() = %q, %q, want %q, %q\nUA: %s", vendor, model, test.vendor, test.model, test.userAgent)
+		}
+	}
+}
+
+func TestModelPrefersHint(t *testing.T) {
+	const reduced = "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.0.0 Mobile Safari/537.36"
+	detect := NewFromHints(reduced, "", ClientHints{Model: "Pixel 8 Pro"})
+	if vendor, model := detect.Vendor(), detect.Model(); vendor != VendorGoogle || model != "Pixel 8 Pro" {
+		t.Errorf("Vendor(), Model() = %q, %q, want google, Pixel 8 Pro", vendor, model)
+	}
+}
@@ -0,0 +1,176 @@
+package mobileesp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a major.minor.patch version number parsed from the User Agent.
+// The zero Version means no version was found.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+//**************************
+// Returns the version as "major.minor.patch", or "" for the zero Version.
+func (v Version) String() string {
+	if v.IsZero() {
+		return ""
+	}
+	return strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor) + "." + strconv.Itoa(v.Patch)
+}
+
+//**************************
+// Reports whether no version was found.
+func (v Version) IsZero() bool {
+	return v == Version{}
+}
+
+//**************************
+// Returns -1, 0 or 1 when v is lower than, equal to or higher than other.
+func (v Version) Compare(other Version) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+//**************************
+// Reports whether v is at least major.minor.patch. Minor and patch are optional,
+//   so AtLeast(4, 4) is true for Android 4.4.2 and 5.0. Always false for the zero Version.
+func (v Version) AtLeast(major int, minorAndPatch ...int) bool {
+	if v.IsZero() {
+		return false
+	}
+	want := Version{Major: major}
+	if len(minorAndPatch) > 0 {
+		want.Minor = minorAndPatch[0]
+	}
+	if len(minorAndPatch) > 1 {
+		want.Patch = minorAndPatch[1]
+	}
+	return v.Compare(want) >= 0
+}
+
+//**************************
+// Tried in order for each platform. The first pattern that matches wins.
+//   Each pattern captures up to three numeric parts.
+var osVersionPatterns = map[Platform][]*regexp.Regexp{
+	PlatformIos: {
+		regexp.MustCompile(`os (\d+)_(\d+)(?:_(\d+))?`),
+		//iPads in desktop mode freeze the OS version. Safari's tracks it.
+		regexp.MustCompile(`version/(\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+	},
+	PlatformAndroid: {
+		regexp.MustCompile(`android (\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+	},
+	PlatformWindowsPhone: {
+		regexp.MustCompile(`windows phone(?: os)? (\d+)(?:\.(\d+))?`),
+	},
+	PlatformWindowsMobile: {
+		regexp.MustCompile(`windows ce (\d+)(?:\.(\d+))?`),
+	},
+	PlatformBlackBerry: {
+		regexp.MustCompile(`rim tablet os (\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+		regexp.MustCompile(`blackberry ?\d+/(\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+		regexp.MustCompile(`version/(\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+	},
+	PlatformSymbian: {
+		regexp.MustCompile(`symbianos/(\d+)(?:\.(\d+))?`),
+		regexp.MustCompile(`symbian/(\d+)(?:\.(\d+))?`),
+		regexp.MustCompile(`series60/(\d+)(?:\.(\d+))?`),
+	},
+	PlatformWebOS: {
+		regexp.MustCompile(`(?:webos|hpwos)/(\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+	},
+	PlatformBada: {
+		regexp.MustCompile(`bada/(\d+)(?:\.(\d+))?`),
+	},
+	PlatformTizen: {
+		regexp.MustCompile(`tizen[ /](\d+)(?:\.(\d+))?`),
+	},
+	PlatformHarmonyOS: {
+		regexp.MustCompile(`(?:harmonyos|openharmony) (\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+	},
+	PlatformKaiOS: {
+		regexp.MustCompile(`kaios/(\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+	},
+	PlatformChromeOS: {
+		regexp.MustCompile(`cros \S+ (\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+	},
+	PlatformRoku: {
+		regexp.MustCompile(`roku/dvp-(\d+)(?:\.(\d+))?`),
+	},
+	PlatformTvOS: {
+		regexp.MustCompile(`os (\d+)_(\d+)(?:_(\d+))?`),
+	},
+	PlatformVidaa: {
+		regexp.MustCompile(`vidaa/(\d+)(?:\.(\d+))?(?:\.(\d+))?`),
+	},
+	PlatformUbuntu: {
+		regexp.MustCompile(`ubuntu (\d+)(?:\.(\d+))?`),
+	},
+}
+
+// Platforms built on Android, whose UAs carry the Android version.
+var androidBasedPlatforms = map[Platform]bool{
+	PlatformFireOS:    true,
+	PlatformHarmonyOS: true,
+}
+
+//**************************
+// Returns the version of the first pattern that matches text.
+func matchVersion(text string, patterns []*regexp.Regexp) Version {
+	for _, pattern := range patterns {
+		if match := pattern.FindStringSubmatch(text); match != nil {
+			return parseVersion(match[1:])
+		}
+	}
+	return Version{}
+}
+
+func parseVersion(parts []string) Version {
+	var numbers [3]int
+	for i := 0; i < len(parts) && i < len(numbers); i++ {
+		numbers[i], _ = strconv.Atoi(parts[i])
+	}
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}
+}
+
+//**************************
+// Returns the operating system version, such as 4.4 for Android 4.4
+//   or 12.1 for iOS 12_1. Returns the zero Version when the platform
+//   is unknown or carries no version. Prefers Sec-CH-UA-Platform-Version
+//   on Android, whose UA string may be frozen. Fire OS, and HarmonyOS
+//   without its own version token, report the Android version.
+func (base *UAgentInfo) OSVersion() Version {
+	platform := base.Device().platform()
+	if platform != PlatformAndroid {
+		version := matchVersion(base.userAgentHeader, osVersionPatterns[platform])
+		if !version.IsZero() || !androidBasedPlatforms[platform] {
+			return version
+		}
+	}
+
+	hints := base.clientHints
+	if strings.EqualFold(hints.Platform, "android") && hints.PlatformVersion != "" {
+		return parseVersion(strings.Split(hints.PlatformVersion, "."))
+	}
+	return matchVersion(base.userAgentHeader, osVersionPatterns[PlatformAndroid])
+}
+
+//**************************
+// Returns the browser version, such as 42.0.2311 for Chrome 42.0.2311.107.
+//   Returns the zero Version when no known browser token is found.
+//   The same as Browser().Version.
+func (base *UAgentInfo) BrowserVersion() Version {
+	return base.Browser().Version
+}
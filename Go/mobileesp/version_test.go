@@ -0,0 +1,82 @@
+package mobileesp
+
+import "testing"
+
+func TestOSVersion(t *testing.T) {
+	const fireTablet = "Mozilla/5.0 (Linux; Android 9; KFMAWI) AppleWebKit/537.36 (KHTML, like Gecko) Silk/119.3.1 like Chrome/119.0.6045.193 Safari/537.36"
+
+	tests := []struct {
+		userAgent string
+		want      Version
+	}{
+		{"Mozilla/5.0 (Linux; Android 4.4.2; Nexus 5 Build/KOT49H) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/34.0.1847.114 Mobile Safari/537.36", Version{4, 4, 2}},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 12_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/12.0 Mobile/15E148 Safari/604.1", Version{12, 1, 0}},
+		{"Mozilla/5.0 (iPad; CPU OS 7_0_6 like Mac OS X) AppleWebKit/537.51.1 (KHTML, like Gecko) Coast/2.0.5.71150 Mobile/11B651 Safari/7534.48.3", Version{7, 0, 6}},
+		{"Mozilla/5.0 (Mobile; Windows Phone 8.1; Android 4.0; ARM; Trident/7.0; Touch; rv:11.0; IEMobile/11.0; NOKIA; Lumia 635) like iPhone OS 7_0_3 Mac OS X AppleWebKit/537 (KHTML, like Gecko) Mobile Safari/537", Version{8, 1, 0}},
+		{"Mozilla/5.0 (BB10; Touch) AppleWebKit/537.35+ (KHTML, like Gecko) Version/10.3.1.2243 Mobile Safari/537.35+", Version{10, 3, 1}},
+		{"BlackBerry9700/5.0.0.207 Profile/MIDP-2.1 Configuration/CLDC-1.1 VendorID/175", Version{5, 0, 0}},
+		{"Mozilla/5.0 (PlayBook; U; RIM Tablet OS 2.1.0; en-US) AppleWebKit/536.2+ (KHTML, like Gecko) Version/7.2.1.0 Safari/536.2+", Version{2, 1, 0}},
+		{"Mozilla/5.0 (Windows NT 6.1; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/42.0.2311.135 Safari/537.36", Version{}},
+		//Fire OS and HarmonyOS report the Android version they are built on.
+		{fireTablet, Version{9, 0, 0}},
+		{"Mozilla/5.0 (Linux; Android 10; HarmonyOS; ANA-AN00; HMSCore 6.11.0.302) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/99.0.4844.88 HuaweiBrowser/14.0.0.322 Mobile Safari/537.36", Version{10, 0, 0}},
+	}
+
+	for _, test := range tests {
+		if got := NewFromStrings(test.userAgent, "").OSVersion(); got != test.want {
+			t.Errorf("OSVersion() = %v, want %v\nUA: %s", got, test.want, test.userAgent)
+		}
+	}
+
+	//Sec-CH-UA-Platform-Version wins over the frozen Android version on Fire OS too.
+	fire := NewFromHints(fireTablet, "", ClientHints{Platform: "Android", PlatformVersion: "11.0.0"})
+	if got := fire.OSVersion(); got != (Version{11, 0, 0}) {
+		t.Errorf("OSVersion() with Sec-CH-UA-Platform-Version 11.0.0 = %v on Fire OS", got)
+	}
+}
+
+func TestBrowserVersion(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		want      Version
+	}{
+		{"mozilla/5.0 (linux; android 4.4.4; xt1053 build/kxa21.12-l1.29.1) applewebkit/537.36 (khtml, like gecko) chrome/42.0.2311.107 mobile safari/537.36 opr/29.0.1809.92117", Version{29, 0, 1809}},
+		{"Mozilla/5.0 (Linux; Android 4.4.2; Nexus 5 Build/KOT49H) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/34.0.1847.114 Mobile Safari/537.36", Version{34, 0, 1847}},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 12_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/12.0 Mobile/15E148 Safari/604.1", Version{12, 0, 0}},
+		{"Mozilla/5.0 (compatible; MSIE 10.0; Windows Phone 8.0; Trident/6.0; IEMobile/10.0; ARM; Touch; NOKIA; Lumia 920)", Version{10, 0, 0}},
+		{"Roku/DVP-5.2 (025.02E03197A)", Version{}},
+		//In-app browsers report the app version, as Browser() does.
+		{"Mozilla/5.0 (Linux; Android 12; SM-G991B Build/SP1A.210812.016; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/114.0.5735.196 Mobile Safari/537.36 [FB_IAB/FB4A;FBAV/420.0.0.32.61;]", Version{420, 0, 0}},
+	}
+
+	for _, test := range tests {
+		detect := NewFromStrings(test.userAgent, "")
+		if got := detect.BrowserVersion(); got != test.want {
+			t.Errorf("BrowserVersion() = %v, want %v\nUA: %s", got, test.want, test.userAgent)
+		}
+		if got := detect.Browser().Version; got != detect.BrowserVersion() {
+			t.Errorf("Browser().Version = %v, BrowserVersion() = %v\nUA: %s", got, detect.BrowserVersion(), test.userAgent)
+		}
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version Version
+		parts   []int
+		want    bool
+	}{
+		{Version{4, 4, 2}, []int{4, 4}, true},
+		{Version{5, 0, 0}, []int{4, 4}, true},
+		{Version{4, 3, 9}, []int{4, 4}, false},
+		{Version{4, 4, 0}, []int{4, 4, 1}, false},
+		{Version{12, 1, 0}, []int{12}, true},
+		{Version{}, []int{0}, false},
+	}
+
+	for _, test := range tests {
+		if got := test.version.AtLeast(test.parts[0], test.parts[1:]...); got != test.want {
+			t.Errorf("%v.AtLeast(%v) = %v, want %v", test.version, test.parts, got, test.want)
+		}
+	}
+}
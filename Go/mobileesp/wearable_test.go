@@ -0,0 +1,45 @@
+package mobileesp
+
+import "testing"
+
+func TestWearableAndAutomotive(t *testing.T) {
+	tests := []struct {
+		userAgent  string
+		formFactor FormFactor
+	}{
+		{"Mozilla/5.0 (Linux; Android 11; SM-R870 Build/RP1A.200720.012; wv) AppleWebKit/537.36 (KHTML, like Gecko) Version/4.0 Chrome/114.0.5735.196 Mobile Safari/537.36", FormFactorWearable},
+		{"Mozilla/5.0 (Linux; Android 13; Google Pixel Watch) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36", FormFactorWearable},
+		{"Mozilla/5.0 (Watch; CPU Watch OS 9_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/9.0 Mobile/19R346 Safari/605.1", FormFactorWearable},
+		{"Mozilla/5.0 (Linux; Tizen 2.3; SAMSUNG SM-R720) AppleWebKit/538.1 (KHTML, like Gecko)Version/2.3 Mobile Safari/538.1", FormFactorWearable},
+		{"Mozilla/5.0 (X11; GNU/Linux) AppleWebKit/537.36 (KHTML, like Gecko) Chromium/79.0.3945.130 Chrome/79.0.3945.130 Safari/537.36 Tesla/2020.16.2.1-e99c70fff409", FormFactorAutomotive},
+		{"Mozilla/5.0 (Linux; Android 10; Polestar 2 Build/QAAS.210531.001) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.164 Safari/537.36", FormFactorAutomotive},
+		{"Mozilla/5.0 (Linux; Android 12; Automotive) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/114.0.5735.196 Mobile Safari/537.36", FormFactorAutomotive},
+	}
+
+	//Watches and cars that send Sec-CH-UA-Mobile must stay out of the phone tiers too.
+	hinted := []ClientHints{
+		{Mobile: true, MobileSent: true},
+		{MobileSent: true},
+	}
+	for _, test := range tests {
+		detections := []*UAgentInfo{NewFromStrings(test.userAgent, "")}
+		for _, hints := range hinted {
+			detections = append(detections, NewFromHints(test.userAgent, "", hints))
+		}
+		for _, detect := range detections {
+			device := detect.Device()
+			if got := device.Wearable(); got != (test.formFactor == FormFactorWearable) {
+				t.Errorf("Wearable() = %v, hints %+v\nUA: %s", got, detect.clientHints, test.userAgent)
+			}
+			if got := device.Automotive(); got != (test.formFactor == FormFactorAutomotive) {
+				t.Errorf("Automotive() = %v, hints %+v\nUA: %s", got, detect.clientHints, test.userAgent)
+			}
+			if device.MobileQuick() || device.TierIphone() || device.TierRichCss() || device.TierOtherPhones() || device.TierTablet() {
+				t.Errorf("in a phone or tablet tier: %+v, hints %+v\nUA: %s", detect.Classify(), detect.clientHints, test.userAgent)
+			}
+			if got := detect.Classify().FormFactor; got != test.formFactor {
+				t.Errorf("FormFactor = %q, want %q, hints %+v\nUA: %s", got, test.formFactor, detect.clientHints, test.userAgent)
+			}
+		}
+	}
+}